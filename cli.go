@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"scheduleTemplate/schedule"
+)
+
+// command is a single stage, or set of stages, runnable from the command line.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"generate", "read the inputs and write the output of every enabled renderer", runGenerate},
+	{"validate", "check the config and every input file and list the problems found", runValidate},
+	{"render", "write the per-day outputs for one date (--date)", runRender},
+	{"export", "write the output of a single renderer (--format excel|csv|html|ics)", runExport},
+	{"slots", "write the open field slots as CSV, JSON and an Excel sheet", runSlots},
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func usage() {
+	fmt.Println("usage: scheduleTemplate <command> [flags]")
+	fmt.Println()
+	fmt.Println("commands:")
+	for _, c := range commands {
+		fmt.Printf("  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Println()
+	fmt.Println("run \"scheduleTemplate <command> -h\" for the flags of a command")
+}
+
+// options are the flags shared by every command.
+type options struct {
+	configPath string
+	inputDir   string
+	outputDir  string
+	jobs       int
+	strict     bool
+	reportPath string
+	logLevel   string
+	logFormat  string
+	quiet      bool
+	dryRun     bool
+	force      bool
+	division   string
+
+	// slots is set by the slots command, where --division filters the
+	// slots written rather than a dry run's plan.
+	slots bool
+}
+
+func newFlagSet(name string, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&o.configPath, "config", schedule.DefaultConfigPath, "path to the season config file")
+	fs.StringVar(&o.inputDir, "input", "", "directory holding the division input files (default inputs.dir from the config)")
+	fs.StringVar(&o.outputDir, "output", "", "directory the output folders are created in (default output.dir from the config, or the working directory)")
+	fs.IntVar(&o.jobs, "jobs", runtime.NumCPU(), "number of files to render at the same time")
+	fs.BoolVar(&o.strict, "strict", false, "fail the run on warnings as well as errors")
+	fs.StringVar(&o.reportPath, "report", "", "write a JSON report of the run to this file")
+	fs.StringVar(&o.logLevel, "log-level", "info", "lowest level logged: debug, info, warn or error")
+	fs.StringVar(&o.logFormat, "log-format", "text", "log format: text or json")
+	fs.BoolVar(&o.quiet, "quiet", false, "log errors only")
+	return fs
+}
+
+// writeFlags adds the flags of the commands that write output files.
+func (o *options) writeFlags(fs *flag.FlagSet) *flag.FlagSet {
+	fs.BoolVar(&o.dryRun, "dry-run", false, "print which files would be created or changed and the open fields filled, without writing anything")
+	fs.BoolVar(&o.force, "force", false, "rebuild every file, even those whose games have not changed")
+	if o.slots {
+		fs.StringVar(&o.division, "division", "", "list only the open fields this division may book")
+	} else {
+		fs.StringVar(&o.division, "division", "", "with --dry-run, list only the open fields this division may book")
+	}
+	return fs
+}
+
+// job is one run of a command: the schedule it works on, its folders and
+// the report of what it did.
+type job struct {
+	sched      *schedule.Schedule
+	inputDir   string
+	outputDir  string
+	renderers  []schedule.Renderer
+	workers    int
+	report     *runReport
+	reportPath string
+	dryRun     bool
+	force      bool
+	division   string
+
+	// clean removes output files this run did not produce. It is set for
+	// commands that render every date.
+	clean bool
+}
+
+// usageError is a mistake in the command line rather than a failed run.
+type usageError struct{ error }
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{fmt.Errorf(format, args...)}
+}
+
+// parse parses args and loads the config they point at.
+func (o *options) parse(fs *flag.FlagSet, args []string) (*job, error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+	if fs.NArg() > 0 {
+		return nil, usagef("%s: unexpected argument %q", fs.Name(), fs.Arg(0))
+	}
+	if o.jobs < 1 {
+		return nil, usagef("%s: --jobs must be at least 1", fs.Name())
+	}
+	if o.division != "" && !o.dryRun && !o.slots {
+		return nil, usagef("%s: --division needs --dry-run", fs.Name())
+	}
+	if err := setupLogging(o.logLevel, o.logFormat, o.quiet); err != nil {
+		return nil, err
+	}
+
+	cfg, err := schedule.LoadConfig(o.configPath)
+	if err != nil {
+		return nil, err
+	}
+	j := &job{
+		sched:      schedule.New(cfg),
+		inputDir:   cfg.Inputs.Dir,
+		outputDir:  cfg.Output.Dir,
+		workers:    o.jobs,
+		report:     &runReport{Command: fs.Name(), Strict: o.strict, Written: []string{}, Unchanged: []string{}, Removed: []string{}, Problems: []problem{}},
+		reportPath: o.reportPath,
+		dryRun:     o.dryRun,
+		force:      o.force,
+		division:   o.division,
+	}
+	if o.inputDir != "" {
+		j.inputDir = o.inputDir
+	}
+	if o.outputDir != "" {
+		j.outputDir = o.outputDir
+	}
+	if j.outputDir == "" {
+		j.outputDir = "."
+	}
+	if j.renderers, err = j.sched.Renderers(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// useRenderers replaces the renderers enabled in the config with the
+// comma-separated names in list.
+func (j *job) useRenderers(list string) error {
+	j.renderers = nil
+	for _, name := range strings.Split(list, ",") {
+		r, err := j.sched.NewRenderer(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		j.renderers = append(j.renderers, r)
+	}
+	return nil
+}
+
+// folder returns the output folder of r, e.g. outputDataExcel.
+func (j *job) folder(r schedule.Renderer) string {
+	name := r.Name()
+	return filepath.Join(j.outputDir, outputFolderPrefix+strings.ToUpper(name[:1])+name[1:])
+}
+
+// load reads the input files, then prints and reports any issues found in
+// them.
+func (j *job) load() error {
+	err := j.sched.Load(j.inputDir)
+	j.report.addIssues(j.sched.Issues)
+	if len(j.sched.Issues) > 0 {
+		j.printIssues()
+	}
+	if errs, _ := j.sched.CountIssues(); err != nil && errs > 0 {
+		return errReported
+	}
+	return err
+}
+
+// finish completes the run report with err and returns the run's error.
+func (j *job) finish(err error) error {
+	return j.report.finish(err, j.reportPath)
+}
+
+// printIssues logs every issue, then a one-line summary.
+func (j *job) printIssues() {
+	j.sched.SortIssues()
+	for _, i := range j.sched.Issues {
+		level := slog.LevelWarn
+		if i.Severity == schedule.SeverityError {
+			level = slog.LevelError
+		}
+		attrs := []any{"file", i.File}
+		if i.Line > 0 {
+			attrs = append(attrs, "row", i.Line)
+		}
+		if i.Column != "" {
+			attrs = append(attrs, "column", i.Column)
+		}
+		slog.Log(context.Background(), level, i.Message, attrs...)
+	}
+	errs, warnings := j.sched.CountIssues()
+	slog.Info("inputs checked", "errors", errs, "warnings", warnings)
+}
+
+func runGenerate(args []string) (err error) {
+	var o options
+	fs := o.writeFlags(newFlagSet("generate", &o))
+	names := fs.String("renderers", "", "comma-separated renderers to run instead of those in the config")
+	j, err := o.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	defer func() { err = j.finish(err) }()
+
+	if *names != "" {
+		if err := j.useRenderers(*names); err != nil {
+			return err
+		}
+	}
+	if err := j.prepareOutput(); err != nil {
+		return err
+	}
+	if err := j.load(); err != nil {
+		return err
+	}
+	j.clean = true
+	j.writeDays(j.sched.Days())
+	return nil
+}
+
+func runValidate(args []string) (err error) {
+	var o options
+	j, err := o.parse(newFlagSet("validate", &o), args)
+	if err != nil {
+		return err
+	}
+	defer func() { err = j.finish(err) }()
+
+	inputs, err := j.sched.Inputs(j.inputDir)
+	if err != nil {
+		return err
+	}
+	err = j.sched.Load(j.inputDir)
+	j.report.addIssues(j.sched.Issues)
+	j.printIssues()
+	if errs, _ := j.sched.CountIssues(); err != nil && errs > 0 {
+		return errReported
+	} else if err != nil {
+		return err
+	}
+	if _, warnings := j.sched.CountIssues(); !o.strict || warnings == 0 {
+		slog.Info("inputs OK", "games", len(j.sched.Games), "files", len(inputs))
+	}
+	return nil
+}
+
+func runRender(args []string) (err error) {
+	var o options
+	fs := o.writeFlags(newFlagSet("render", &o))
+	date := fs.String("date", "", "date to render, e.g. 1/4/2025")
+	j, err := o.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	defer func() { err = j.finish(err) }()
+
+	if *date == "" {
+		return usagef("render: --date is required")
+	}
+	var perDay []schedule.Renderer
+	for _, r := range j.renderers {
+		if r.Scope() == schedule.PerDay {
+			perDay = append(perDay, r)
+		}
+	}
+	j.renderers = perDay
+	if err := j.prepareOutput(); err != nil {
+		return err
+	}
+	if err := j.load(); err != nil {
+		return err
+	}
+	d, err := j.sched.Day(*date)
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	j.writeDays([]schedule.Day{d})
+	return nil
+}
+
+func runExport(args []string) (err error) {
+	var o options
+	fs := o.writeFlags(newFlagSet("export", &o))
+	format := fs.String("format", "", "renderer to run: "+strings.Join(schedule.RendererNames(), ", ")+" (xlsx is excel)")
+	j, err := o.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	defer func() { err = j.finish(err) }()
+
+	switch *format {
+	case "":
+		return usagef("export: --format is required")
+	case "xlsx":
+		*format = "excel"
+	}
+	if err := j.useRenderers(*format); err != nil {
+		return usagef("export: %v", err)
+	}
+	if err := j.prepareOutput(); err != nil {
+		return err
+	}
+	if err := j.load(); err != nil {
+		return err
+	}
+	j.clean = true
+	j.writeDays(j.sched.Days())
+	return nil
+}
+
+func runSlots(args []string) (err error) {
+	o := options{slots: true}
+	fs := o.writeFlags(newFlagSet("slots", &o))
+	formats := fs.String("format", strings.Join(schedule.SlotFormats, ","), "comma-separated formats to write: "+strings.Join(schedule.SlotFormats, ", ")+" (xlsx is excel)")
+	from := fs.String("from", "", "first date to list, e.g. 1/4/2025")
+	to := fs.String("to", "", "last date to list")
+	venue := fs.String("venue", "", "list only the open fields at this venue")
+	size := fs.String("size", "", "list only the open fields of this size")
+	j, err := o.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	defer func() { err = j.finish(err) }()
+
+	filter := schedule.SlotFilter{Size: strings.TrimSpace(*size), Division: o.division}
+	cfg := j.sched.Config
+	if *from != "" {
+		if filter.From, err = cfg.ParseDate(*from); err != nil {
+			return usagef("slots: --from %q: %v", *from, err)
+		}
+	}
+	if *to != "" {
+		if filter.To, err = cfg.ParseDate(*to); err != nil {
+			return usagef("slots: --to %q: %v", *to, err)
+		}
+	}
+	if *venue != "" {
+		v, ok := cfg.VenueNamed(*venue)
+		if !ok {
+			return usagef("slots: --venue: unknown venue %q", *venue)
+		}
+		filter.Venue = v.Name
+	}
+	if *size != "" && !cfg.HasSize(*size) {
+		sizes := strings.Join(cfg.Sizes(), ", ")
+		if sizes == "" {
+			sizes = "no field has a size"
+		}
+		return usagef("slots: --size: unknown field size %q (%s)", *size, sizes)
+	}
+
+	j.renderers = nil
+	seen := make(map[string]bool)
+	for _, format := range strings.Split(*formats, ",") {
+		format = strings.TrimSpace(format)
+		if format == "xlsx" {
+			format = "excel"
+		}
+		if seen[format] {
+			continue
+		}
+		seen[format] = true
+		r, err := j.sched.SlotsRenderer(format)
+		if err != nil {
+			return usagef("slots: %v", err)
+		}
+		j.renderers = append(j.renderers, r)
+	}
+	if err := j.prepareOutput(); err != nil {
+		return err
+	}
+	if err := j.load(); err != nil {
+		return err
+	}
+	j.writeDays(j.sched.OpenDays(j.sched.Days(), filter))
+	return nil
+}
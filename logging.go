@@ -0,0 +1,32 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// setupLogging sends log records at or above level to stderr, as text or
+// JSON. quiet keeps only errors.
+func setupLogging(level, format string, quiet bool) error {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return usagef("--log-level: unknown level %q (want debug, info, warn or error)", level)
+	}
+	if quiet {
+		lvl = slog.LevelError
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "text":
+		h = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		h = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return usagef("--log-format: unknown format %q (want text or json)", format)
+	}
+	slog.SetDefault(slog.New(h))
+	return nil
+}
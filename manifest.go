@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"scheduleTemplate/schedule"
+)
+
+// manifestName is the file, in the output directory, that records what each
+// output file was last built from.
+const manifestName string = ".schedule-manifest.json"
+
+// manifest maps each output file, relative to the output directory, to the
+// schedule.Fingerprint it was last written with.
+type manifest struct {
+	Version int               `json:"version"`
+	Files   map[string]string `json:"files"`
+}
+
+const manifestVersion = 1
+
+func newManifest() *manifest {
+	return &manifest{Version: manifestVersion, Files: make(map[string]string)}
+}
+
+// loadManifest reads the manifest in dir. A missing or outdated manifest is
+// treated as empty, so every file is rebuilt.
+func loadManifest(dir string) (*manifest, error) {
+	m := newManifest()
+	raw, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved manifest
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		return nil, fmt.Errorf("%s: %w", manifestName, err)
+	}
+	if saved.Version == manifestVersion && saved.Files != nil {
+		m.Files = saved.Files
+	}
+	return m, nil
+}
+
+// save writes the manifest to dir.
+func (m *manifest) save(dir string) error {
+	out, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return schedule.WriteFile(filepath.Join(dir, manifestName), func(w io.Writer) error {
+		_, err := w.Write(append(out, '\n'))
+		return err
+	})
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"scheduleTemplate/schedule"
+)
+
+// prepareOutput creates the output folder of every renderer. A dry run
+// creates nothing.
+func (j *job) prepareOutput() error {
+	if j.dryRun {
+		return nil
+	}
+	for _, r := range j.renderers {
+		if err := os.MkdirAll(j.folder(r), os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// task is one artifact to render.
+type task struct {
+	r    schedule.Renderer
+	days []schedule.Day
+	path string
+
+	// key names the file in the manifest and fingerprint is what it is
+	// built from.
+	key         string
+	fingerprint string
+}
+
+// What a run does, or in a dry run would do, to an output file.
+const (
+	actionCreate    = "create"
+	actionChange    = "change"
+	actionUnchanged = "unchanged"
+	actionRemove    = "remove"
+)
+
+// writeDays runs every renderer over days: per-day renderers once for each
+// day and season renderers once for all of them. Up to j.workers artifacts
+// are rendered at a time. Every artifact is attempted and each failure or
+// warning is added to the run report. A dry run renders in memory and
+// prints the plan instead of writing.
+//
+// Files whose fingerprint matches the manifest are left alone unless
+// j.force is set, so their modification times only change with their games.
+func (j *job) writeDays(days []schedule.Day) {
+	var tasks []task
+	for _, r := range j.renderers {
+		if r.Scope() == schedule.PerSeason {
+			tasks = append(tasks, j.newTask(r, days))
+			continue
+		}
+		for _, d := range days {
+			tasks = append(tasks, j.newTask(r, []schedule.Day{d}))
+		}
+	}
+
+	m, err := loadManifest(j.outputDir)
+	if err != nil {
+		slog.Warn("rebuilding every file: "+err.Error(), "file", filepath.Join(j.outputDir, manifestName))
+		j.report.add(schedule.SeverityWarning, filepath.Join(j.outputDir, manifestName), 0, "", err.Error())
+		m = newManifest()
+	}
+
+	run := task.write
+	if j.dryRun {
+		run = task.plan
+	}
+	actions := make([]string, len(tasks))
+	errs := make([]error, len(tasks))
+	var pending []int
+	for i, t := range tasks {
+		if !j.dryRun && !j.force && m.Files[t.key] == t.fingerprint && fileExists(t.path) {
+			actions[i] = actionUnchanged
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < j.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				actions[i], errs[i] = run(tasks[i])
+			}
+		}()
+	}
+	for _, i := range pending {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, t := range tasks {
+		var warnings schedule.Warnings
+		switch {
+		case errors.As(errs[i], &warnings):
+			for _, w := range warnings {
+				slog.Warn(w.Error(), t.attrs()...)
+				j.report.add(schedule.SeverityWarning, t.path, 0, "", w.Error())
+			}
+		case errs[i] != nil:
+			slog.Error(errs[i].Error(), t.attrs()...)
+			j.report.add(schedule.SeverityError, t.path, 0, "", errs[i].Error())
+			actions[i] = ""
+			delete(m.Files, t.key)
+			continue
+		}
+		switch {
+		case j.dryRun:
+			j.report.Plan = append(j.report.Plan, plannedFile{t.path, actions[i]})
+		case actions[i] == actionUnchanged:
+			slog.Debug("up to date", t.attrs()...)
+			j.report.Unchanged = append(j.report.Unchanged, t.path)
+		default:
+			slog.Info("wrote", append(t.attrs(), "action", actions[i])...)
+			j.report.Written = append(j.report.Written, t.path)
+			// A file written with warnings is rebuilt next time, so the
+			// warnings are reported again until they are fixed.
+			if len(warnings) > 0 {
+				delete(m.Files, t.key)
+			} else {
+				m.Files[t.key] = t.fingerprint
+			}
+		}
+	}
+	var orphans []string
+	if j.clean {
+		orphans = j.orphans(tasks, m)
+	}
+	if j.dryRun {
+		for _, path := range orphans {
+			j.report.Plan = append(j.report.Plan, plannedFile{path, actionRemove})
+		}
+		printPlan(tasks, actions, orphans, days, j.canBook)
+		return
+	}
+	j.removeOrphans(orphans, m)
+	slog.Info("output written", "written", len(j.report.Written), "unchanged", len(j.report.Unchanged), "removed", len(j.report.Removed))
+	if err := m.save(j.outputDir); err != nil {
+		slog.Error(err.Error(), "file", filepath.Join(j.outputDir, manifestName))
+		j.report.add(schedule.SeverityError, filepath.Join(j.outputDir, manifestName), 0, "", err.Error())
+	}
+}
+
+// orphans returns the output files, in the folders of j's renderers, that
+// this run did not produce: outputs for dates no longer in the inputs and
+// temporary files left by an interrupted run. Only files named the way a
+// renderer names them are considered.
+func (j *job) orphans(tasks []task, m *manifest) []string {
+	produced := make(map[string]bool)
+	for _, t := range tasks {
+		produced[t.path] = true
+	}
+	var orphans []string
+	for _, r := range j.renderers {
+		entries, err := os.ReadDir(j.folder(r))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			path := filepath.Join(j.folder(r), e.Name())
+			if e.IsDir() || produced[path] || !schedule.IsArtifactFile(e.Name()) {
+				continue
+			}
+			if !strings.HasPrefix(e.Name(), ".") && filepath.Ext(e.Name()) != r.Ext() {
+				continue
+			}
+			orphans = append(orphans, path)
+		}
+	}
+	return orphans
+}
+
+// removeOrphans deletes orphans and their manifest entries.
+func (j *job) removeOrphans(orphans []string, m *manifest) {
+	for _, path := range orphans {
+		if err := os.Remove(path); err != nil {
+			slog.Warn(err.Error(), "file", path)
+			j.report.add(schedule.SeverityWarning, path, 0, "", err.Error())
+			continue
+		}
+		slog.Info("removed", "file", path)
+		j.report.Removed = append(j.report.Removed, path)
+		if key, err := filepath.Rel(j.outputDir, path); err == nil {
+			delete(m.Files, filepath.ToSlash(key))
+		}
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func (j *job) newTask(r schedule.Renderer, days []schedule.Day) task {
+	path := filepath.Join(j.folder(r), schedule.ArtifactName(r, days)+r.Ext())
+	key, err := filepath.Rel(j.outputDir, path)
+	if err != nil {
+		key = path
+	}
+	return task{r, days, path, filepath.ToSlash(key), j.sched.Fingerprint(r, days)}
+}
+
+// attrs are the log fields naming the task's file, renderer and date.
+func (t task) attrs() []any {
+	attrs := []any{"file", t.path, "renderer", t.r.Name()}
+	if t.r.Scope() == schedule.PerDay {
+		attrs = append(attrs, "date", t.days[0].Date.Format(schedule.DateFormat))
+	}
+	return attrs
+}
+
+// write renders the task's days into its file. The file is replaced
+// atomically, so a failed render leaves the previous version in place.
+func (t task) write() (string, error) {
+	action := actionChange
+	if !fileExists(t.path) {
+		action = actionCreate
+	}
+	err := schedule.WriteFile(t.path, func(w io.Writer) error {
+		return t.r.Render(w, t.days)
+	})
+	var warnings schedule.Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		return "", err
+	}
+	return action, err
+}
+
+// plan renders the task's days in memory and compares them with the file
+// already on disk.
+func (t task) plan() (string, error) {
+	var buf bytes.Buffer
+	err := t.r.Render(&buf, t.days)
+	var warnings schedule.Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		return "", err
+	}
+	old, rerr := os.ReadFile(t.path)
+	switch {
+	case errors.Is(rerr, fs.ErrNotExist):
+		return actionCreate, err
+	case rerr != nil:
+		return "", rerr
+	case bytes.Equal(old, buf.Bytes()):
+		return actionUnchanged, err
+	}
+	return actionChange, err
+}
+
+// printPlan prints what a run would do to each file, then each date's
+// games and the open fields that would be filled in, under their venue on
+// dates played at more than one. Open fields only some divisions may book
+// are followed by those divisions. Only the open fields keep accepts are
+// listed.
+func printPlan(tasks []task, actions, orphans []string, days []schedule.Day, keep func(schedule.Game) bool) {
+	counts := make(map[string]int)
+	fmt.Println("Files:")
+	for i, t := range tasks {
+		action := actions[i]
+		if action == "" {
+			action = "fail"
+		}
+		counts[action]++
+		fmt.Printf("  %-10s %s\n", action, t.path)
+	}
+	for _, path := range orphans {
+		counts[actionRemove]++
+		fmt.Printf("  %-10s %s\n", actionRemove, path)
+	}
+
+	fmt.Println("Dates:")
+	for _, d := range days {
+		var games, closed int
+		var open []schedule.Game
+		venues := make(map[string]bool)
+		for _, g := range d.Games {
+			venues[g.Venue] = true
+			switch {
+			case g.IsOpen():
+				if keep(g) {
+					open = append(open, g)
+				}
+			case g.Closed:
+				closed++
+			default:
+				games++
+			}
+		}
+		fmt.Printf("  %-10s %d %s, %d open %s", d.Date.Format(schedule.DateFormat),
+			games, schedule.Plural(games, "game", "games"), len(open), schedule.Plural(len(open), "field", "fields"))
+		if closed > 0 {
+			fmt.Printf(", %d closed", closed)
+		}
+		fmt.Println()
+		indent := "    "
+		for i := 0; i < len(open); {
+			if len(venues) > 1 && (i == 0 || open[i].Venue != open[i-1].Venue) {
+				fmt.Printf("    %s\n", open[i].Venue)
+				indent = "      "
+			}
+			start, venue := open[i].Start, open[i].Venue
+			var fields []string
+			for ; i < len(open) && open[i].Start.Equal(start) && open[i].Venue == venue; i++ {
+				field := open[i].Location
+				if open[i].Divisions != nil {
+					field += " (" + strings.Join(open[i].Divisions, ", ") + ")"
+				}
+				fields = append(fields, field)
+			}
+			fmt.Printf("%s%-8s %s\n", indent, start.Format("3:04 PM"), strings.Join(fields, ", "))
+		}
+	}
+
+	fmt.Printf("%d to create, %d to change, %d unchanged, %d to remove", counts[actionCreate], counts[actionChange], counts[actionUnchanged], counts[actionRemove])
+	if counts["fail"] > 0 {
+		fmt.Printf(", %d failing", counts["fail"])
+	}
+	fmt.Println()
+}
+
+// canBook reports whether the division given with --division may book the
+// open field g. Without --division every open field is listed.
+func (j *job) canBook(g schedule.Game) bool {
+	if j.division == "" {
+		return true
+	}
+	_, f, err := j.sched.Config.Locate(g.Venue, g.Location)
+	return err == nil && j.sched.Config.Allows(j.division, f)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"scheduleTemplate/schedule"
+)
+
+// errReported is returned by stages whose problems are already in the run
+// report, so the failure is not recorded twice.
+var errReported = errors.New("problems already reported")
+
+// runReport is everything a run wrote and every problem it hit. With
+// --report it is saved as JSON for scripts that publish the output.
+type runReport struct {
+	Command   string    `json:"command"`
+	OK        bool      `json:"ok"`
+	Strict    bool      `json:"strict"`
+	Errors    int       `json:"errors"`
+	Warnings  int       `json:"warnings"`
+	Written   []string  `json:"written"`
+	Unchanged []string  `json:"unchanged"`
+	Removed   []string  `json:"removed"`
+	Problems  []problem `json:"problems"`
+
+	// Plan is what a dry run would have done to each file.
+	Plan []plannedFile `json:"plan,omitempty"`
+}
+
+// plannedFile is one file a dry run would create, change or leave alone.
+type plannedFile struct {
+	File   string `json:"file"`
+	Action string `json:"action"`
+}
+
+// problem is one error or warning, from an input row, an output file or
+// the run as a whole.
+type problem struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   string `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (r *runReport) add(sev schedule.Severity, file string, line int, column, msg string) {
+	r.Problems = append(r.Problems, problem{sev.String(), file, line, column, msg})
+	if sev == schedule.SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// addIssues adds the problems found in the input files.
+func (r *runReport) addIssues(issues []schedule.Issue) {
+	for _, i := range issues {
+		r.add(i.Severity, i.File, i.Line, i.Column, i.Message)
+	}
+}
+
+// finish records err, the outcome of the command, and decides whether the
+// run failed: on any error, or on any warning in strict mode. The report is
+// saved to path when it is not empty.
+func (r *runReport) finish(err error, path string) error {
+	if err != nil && !errors.Is(err, errReported) {
+		r.add(schedule.SeverityError, "", 0, "", err.Error())
+	}
+	r.OK = r.Errors == 0 && !(r.Strict && r.Warnings > 0)
+
+	var saveErr error
+	if path != "" {
+		out, err := json.MarshalIndent(r, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, append(out, '\n'), 0o644)
+		}
+		saveErr = err
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, errReported):
+		return err
+	case !r.OK && r.Errors == 0:
+		return fmt.Errorf("%s failed: %d %s in strict mode", r.Command, r.Warnings, schedule.Plural(r.Warnings, "warning", "warnings"))
+	case !r.OK:
+		return fmt.Errorf("%s failed: %d %s, %d %s", r.Command, r.Errors, schedule.Plural(r.Errors, "error", "errors"), r.Warnings, schedule.Plural(r.Warnings, "warning", "warnings"))
+	case saveErr != nil:
+		return fmt.Errorf("writing report: %w", saveErr)
+	}
+	return nil
+}
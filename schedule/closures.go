@@ -0,0 +1,166 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Closure is a field, or a whole venue, that cannot be played on from From
+// to To, both inclusive.
+type Closure struct {
+	Venue string
+	// Field is the number of the closed field, or 0 when every field at the
+	// venue is closed.
+	Field    int
+	From, To time.Time
+	Reason   string
+
+	// Source and Line locate the row of the closures file.
+	Source string
+	Line   int
+}
+
+// closureColumns are the columns of a closures file. Only From is required;
+// a blank Venue means the first venue, a blank Field every field and a
+// blank To the From date.
+var closureColumns = []string{"Venue", "Field", "From", "To", "Reason"}
+
+// ClosedLabel returns the team name shown on both sides of a closed field.
+func ClosedLabel(reason string) string {
+	if reason == "" {
+		return "CLOSED"
+	}
+	return "CLOSED – " + reason
+}
+
+// isClosedLabel reports whether team is a ClosedLabel.
+func isClosedLabel(team string) bool {
+	return team == ClosedLabel("") || strings.HasPrefix(team, ClosedLabel("")+" – ")
+}
+
+// readClosures reads the closures file at path into s.Closures. Problems with
+// single rows are added to s.Issues; the error is for a file that cannot be
+// read at all.
+func (s *Schedule) readClosures(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	index := make(map[string]int)
+	for i, h := range header {
+		h = normalizeHeader(strings.TrimPrefix(h, "\ufeff"))
+		for _, name := range closureColumns {
+			if h == normalizeHeader(name) {
+				index[name] = i
+			}
+		}
+	}
+	if _, ok := index["From"]; !ok {
+		return fmt.Errorf("header: missing From column")
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		get := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if c, ok := s.parseClosure(path, line, get); ok {
+			s.Closures = append(s.Closures, c)
+		}
+	}
+}
+
+// parseClosure returns the closure on one row of a closures file, reporting
+// what is wrong with it.
+func (s *Schedule) parseClosure(path string, line int, get func(string) string) (Closure, bool) {
+	c := Closure{Reason: get("Reason"), Source: path, Line: line}
+	venue := s.Config.Venues[0]
+	if name := get("Venue"); name != "" {
+		var ok bool
+		if venue, ok = s.Config.VenueNamed(name); !ok {
+			s.report(path, line, "Venue", SeverityError, "%q is not one of the venues (%s)", name, venueNames(s.Config.Venues))
+			return Closure{}, false
+		}
+	}
+	c.Venue = venue.Name
+	if name := get("Field"); name != "" {
+		f, ok := venue.Field(name)
+		if !ok {
+			s.report(path, line, "Field", SeverityError, "%q is not one of the fields at %s (%s)", name, venue.Name, fieldNames(venue.FieldList()))
+			return Closure{}, false
+		}
+		c.Field = f.Number
+	}
+
+	var err error
+	if c.From, err = s.Config.ParseDate(get("From")); err != nil {
+		s.report(path, line, "From", SeverityError, "%q: %v", get("From"), err)
+		return Closure{}, false
+	}
+	c.To = c.From
+	if to := get("To"); to != "" {
+		if c.To, err = s.Config.ParseDate(to); err != nil {
+			s.report(path, line, "To", SeverityError, "%q: %v", to, err)
+			return Closure{}, false
+		}
+		if c.To.Before(c.From) {
+			s.report(path, line, "To", SeverityError, "%s is before %s", to, get("From"))
+			return Closure{}, false
+		}
+	}
+	return c, true
+}
+
+// closure returns the closure covering field number at venue on day.
+func (s *Schedule) closure(venue string, field int, day time.Time) (Closure, bool) {
+	for _, c := range s.Closures {
+		if c.Venue != venue || (c.Field != 0 && c.Field != field) {
+			continue
+		}
+		if !day.Before(c.From) && !day.After(c.To) {
+			return c, true
+		}
+	}
+	return Closure{}, false
+}
+
+// checkClosure reports a game booked on a closed field.
+func (s *Schedule) checkClosure(g Game) {
+	c, ok := s.closure(g.Venue, g.Field, g.Day())
+	if !ok || g.Field == 0 {
+		return
+	}
+	reason := ""
+	if c.Reason != "" {
+		reason = " (" + c.Reason + ")"
+	}
+	s.report(g.Source, g.Line, "Location", SeverityError, "%s at %s is closed on %s%s", g.Location, g.Venue, g.Date(), reason)
+}
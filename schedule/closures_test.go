@@ -0,0 +1,166 @@
+package schedule
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseClosure(t *testing.T) {
+	c := testConfig(t, nil)
+	jan := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		row     map[string]string
+		want    Closure
+		wantErr string
+	}{
+		{
+			row:  map[string]string{"From": "1/4/2025"},
+			want: Closure{Venue: "Ballpark", From: jan(4), To: jan(4)},
+		},
+		{
+			row:  map[string]string{"Venue": "lakeside", "Field": "Main", "From": "1/4/2025", "To": "1/6/2025", "Reason": "Fair"},
+			want: Closure{Venue: "Lakeside", Field: 1, From: jan(4), To: jan(6), Reason: "Fair"},
+		},
+		{
+			row:  map[string]string{"Field": "#2", "From": "2025-01-04"},
+			want: Closure{Venue: "Ballpark", Field: 2, From: jan(4), To: jan(4)},
+		},
+		{row: map[string]string{"Venue": "Hilltop", "From": "1/4/2025"}, wantErr: `Venue: "Hilltop" is not one of the venues`},
+		{row: map[string]string{"Field": "East", "From": "1/4/2025"}, wantErr: `Field: "East" is not one of the fields at Ballpark`},
+		{row: map[string]string{"Venue": "Lakeside", "Field": "North", "From": "1/4/2025"}, wantErr: `Field: "North" is not one of the fields at Lakeside`},
+		{row: map[string]string{}, wantErr: `From: "": not a date`},
+		{row: map[string]string{"From": "Jan 4"}, wantErr: `From: "Jan 4": not a date`},
+		{row: map[string]string{"From": "1/4/2025", "To": "soon"}, wantErr: `To: "soon": not a date`},
+		{row: map[string]string{"From": "1/6/2025", "To": "1/4/2025"}, wantErr: "To: 1/4/2025 is before 1/6/2025"},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c}
+		got, ok := s.parseClosure("closures.csv", 3, func(name string) string { return tt.row[name] })
+		if tt.wantErr != "" {
+			if ok || len(s.Issues) != 1 {
+				t.Errorf("parseClosure(%v) = %+v, %v with issues %v, want one issue", tt.row, got, ok, s.Issues)
+				continue
+			}
+			issue := s.Issues[0]
+			msg := issue.Column + ": " + issue.Message
+			if issue.Severity != SeverityError || issue.Line != 3 || !strings.HasPrefix(msg, tt.wantErr) {
+				t.Errorf("parseClosure(%v) reported %+v, want an error at line 3 starting %q", tt.row, issue, tt.wantErr)
+			}
+			continue
+		}
+		tt.want.Source, tt.want.Line = "closures.csv", 3
+		if !ok || len(s.Issues) > 0 || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseClosure(%v) = %+v, %v with issues %v, want %+v", tt.row, got, ok, s.Issues, tt.want)
+		}
+	}
+}
+
+func TestFillDayClosures(t *testing.T) {
+	c := testConfig(t, noSizes)
+	jan := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	closures := []Closure{
+		{Venue: "Lakeside", From: jan(11), To: jan(11), Reason: "Fair"},
+		{Venue: "Ballpark", Field: 2, From: jan(4), To: jan(11)},
+	}
+	tests := []struct {
+		name  string
+		day   int
+		games []Game
+		want  []string
+	}{
+		{
+			name:  "closed field inside the range",
+			day:   4,
+			games: []Game{testGame(t, c, 4, "9:00", "", "North", "10U", "Red", "Blue")},
+			want: []string{
+				"09:00 Ballpark North: 10U Red vs 10U Blue",
+				"09:00 Ballpark South: closed CLOSED",
+				"10:30 Ballpark North: open",
+				"10:30 Ballpark South: closed CLOSED",
+			},
+		},
+		{
+			name: "closed venue with a reason",
+			day:  11,
+			want: []string{
+				"09:00 Ballpark North: open",
+				"09:00 Ballpark South: closed CLOSED",
+				"10:30 Ballpark North: open",
+				"10:30 Ballpark South: closed CLOSED",
+				"09:00 Lakeside Main: closed CLOSED – Fair",
+				"10:30 Lakeside Main: closed CLOSED – Fair",
+			},
+		},
+		{
+			name: "after the closures",
+			day:  18,
+			want: []string{
+				"09:00 Ballpark North: open",
+				"09:00 Ballpark South: open",
+				"09:00 Lakeside Main: open",
+			},
+		},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c, Games: tt.games, Closures: closures}
+		if got := filledDay(s, tt.day, tt.games); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckClosure(t *testing.T) {
+	c := testConfig(t, nil)
+	closures := []Closure{{Venue: "Ballpark", Field: 2, From: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), Reason: "Resodding"}}
+	tests := []struct {
+		day      int
+		location string
+		want     string
+	}{
+		{4, "South", "South at Ballpark is closed on 1/4/2025 (Resodding)"},
+		{4, "North", ""},
+		{11, "South", ""},
+		{4, "Lakeside - Main", ""},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c, Closures: closures}
+		s.checkClosure(testGame(t, c, tt.day, "9:00", "", tt.location, "10U", "Red", "Blue"))
+		got := ""
+		if len(s.Issues) > 0 {
+			got = s.Issues[0].Message
+		}
+		if got != tt.want || (got != "" && s.Issues[0].Severity != SeverityError) {
+			t.Errorf("game on %s on 1/%d: issues %v, want %q", tt.location, tt.day, s.Issues, tt.want)
+		}
+	}
+}
+
+func TestClosedFieldRoundTrip(t *testing.T) {
+	c := testConfig(t, nil)
+	s := &Schedule{Config: c}
+	venue, field, _ := c.Locate("Lakeside", "Main")
+	start := time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC)
+	for _, reason := range []string{"", "Fair"} {
+		closed := newClosedField(start, venue, field, reason)
+		closed.Attrs = map[string]string{}
+		header := s.CSVColumns()
+		got, err := s.GameFromRecord(header, GameToRecord(closed, header))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, closed) {
+			t.Errorf("closed field round trip:\ngot  %+v\nwant %+v", got, closed)
+		}
+	}
+
+	got, err := s.GameFromRecord([]string{"Home", "Away", "Date", "Time", "Location", "Division"},
+		[]string{"10U CLOSED – Fair", "10U Blue", "1/11/2025", "9:00", "North", "10U"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Closed || got.Division != "10U" {
+		t.Errorf("game against a team named CLOSED read as %+v, want a 10U game", got)
+	}
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// coreColumns are the columns every division file must have, in output order.
+var coreColumns = []string{"Home", "Away", "Date", "Time", "Location"}
+
+// divisionColumn is the optional column that overrides the division label.
+const divisionColumn string = "Division"
+
+// venueColumn is the optional column that names the venue, for files that
+// keep it out of the Location column.
+const venueColumn string = "Venue"
+
+// optionalColumns are the known columns a division file may leave out.
+var optionalColumns = []string{divisionColumn, venueColumn}
+
+// defaultAliases are the header names accepted for each column on top of
+// the column's own name and the aliases in the config.
+var defaultAliases = map[string][]string{
+	"Home":     {"Home Team"},
+	"Away":     {"Away Team", "Visitor", "Visiting Team"},
+	"Location": {"Field"},
+}
+
+// columnMap maps the columns of one input file by header name.
+type columnMap struct {
+	index      map[string]int
+	extras     map[string]int
+	extraNames []string
+}
+
+// mapColumns matches header against the known columns and their aliases.
+// Unknown headers are kept as extra columns.
+func (c *Config) mapColumns(header []string) (*columnMap, error) {
+	lookup := make(map[string]string)
+	for _, name := range append(append([]string{}, coreColumns...), optionalColumns...) {
+		lookup[normalizeHeader(name)] = name
+		for _, alias := range defaultAliases[name] {
+			lookup[normalizeHeader(alias)] = name
+		}
+		for _, alias := range c.Columns[name] {
+			lookup[normalizeHeader(alias)] = name
+		}
+	}
+
+	m := &columnMap{index: make(map[string]int), extras: make(map[string]int)}
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if h == "" {
+			continue
+		}
+		name, ok := lookup[normalizeHeader(h)]
+		if !ok {
+			m.extras[h] = i
+			m.extraNames = append(m.extraNames, h)
+			continue
+		}
+		if _, dup := m.index[name]; dup {
+			return nil, fmt.Errorf("more than one column maps to %s", name)
+		}
+		m.index[name] = i
+	}
+
+	for _, name := range coreColumns {
+		if _, ok := m.index[name]; !ok {
+			return nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+	return m, nil
+}
+
+// get returns the value of the named column in record, or "" if the record
+// is too short or the file has no such column.
+func (m *columnMap) get(record []string, name string) string {
+	i, ok := m.index[name]
+	if !ok {
+		i, ok = m.extras[name]
+	}
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isKnownColumn(name string) bool {
+	for _, c := range append(append([]string{}, coreColumns...), optionalColumns...) {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addColumn records an extra input column so it is carried through to
+// every output.
+func (s *Schedule) addColumn(name string) {
+	for _, c := range s.Columns {
+		if c == name {
+			return
+		}
+	}
+	s.Columns = append(s.Columns, name)
+}
+
+func normalizeHeader(h string) string {
+	return strings.ToLower(strings.Join(strings.Fields(h), " "))
+}
@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapColumns(t *testing.T) {
+	c := &Config{Columns: map[string][]string{"Location": {"Diamond"}, "Home": {"Hosts"}}}
+	tests := []struct {
+		header  []string
+		index   map[string]int
+		extras  []string
+		wantErr bool
+	}{
+		{
+			header: []string{"Home", "Away", "Date", "Time", "Location"},
+			index:  map[string]int{"Home": 0, "Away": 1, "Date": 2, "Time": 3, "Location": 4},
+		},
+		{
+			header: []string{"\ufeffDate", " time ", "FIELD", "Visitor", "Home  Team", "Notes", "", "GameID"},
+			index:  map[string]int{"Date": 0, "Time": 1, "Location": 2, "Away": 3, "Home": 4},
+			extras: []string{"Notes", "GameID"},
+		},
+		{
+			header: []string{"Hosts", "Away", "Date", "Time", "Diamond", "Division", "Venue"},
+			index:  map[string]int{"Home": 0, "Away": 1, "Date": 2, "Time": 3, "Location": 4, "Division": 5, "Venue": 6},
+		},
+		{header: []string{"Home", "Home Team", "Away", "Date", "Time", "Location"}, wantErr: true},
+		{header: []string{"Home", "Away", "Date", "Time", "Field", "Diamond"}, wantErr: true},
+		{header: []string{"Home", "Away", "Date", "Location"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		m, err := c.mapColumns(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("mapColumns(%q): error = %v, want error %v", tt.header, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(m.index, tt.index) {
+			t.Errorf("mapColumns(%q) index = %v, want %v", tt.header, m.index, tt.index)
+		}
+		if !reflect.DeepEqual(m.extraNames, tt.extras) {
+			t.Errorf("mapColumns(%q) extras = %q, want %q", tt.header, m.extraNames, tt.extras)
+		}
+	}
+}
+
+func TestColumnMapGet(t *testing.T) {
+	m, err := (&Config{}).mapColumns([]string{"Home", "Away", "Date", "Time", "Location", "Notes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := []string{" Red ", "Blue", "1/4/2025", "9:00", "Field #1", " rain date "}
+	for name, want := range map[string]string{"Home": "Red", "Notes": "rain date", "Division": "", "Referee": ""} {
+		if got := m.get(record, name); got != want {
+			t.Errorf("get(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := m.get(record[:2], "Location"); got != "" {
+		t.Errorf("get on a short record = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,287 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// configVersion is the current season config schema version. Version 1
+// configs, which have a single venue, are still read.
+const configVersion = 2
+
+// DefaultConfigPath is where the command line tool looks for the config.
+const DefaultConfigPath string = "season.json"
+
+// Config holds everything that changes from one season to the next.
+type Config struct {
+	Version int          `json:"version"`
+	Season  SeasonConfig `json:"season"`
+	Style   StyleConfig  `json:"style"`
+	Page    PageConfig   `json:"page"`
+	Inputs  InputsConfig `json:"inputs"`
+	Output  OutputConfig `json:"output"`
+
+	// Venues lists the parks games are played at. A game's Location names
+	// its venue as "<venue> - <field>"; without one the first venue is
+	// meant.
+	Venues []VenueConfig `json:"venues"`
+
+	// FieldSizes maps a division, as it prefixes the team names, to the
+	// field sizes it may play on, e.g. "15U": ["full"]. Divisions that are
+	// not listed may play on any field.
+	FieldSizes map[string][]string `json:"fieldSizes"`
+
+	// Timezone is the IANA name of the league's time zone, e.g.
+	// "America/New_York". Calendar times given in UTC are read as the clock
+	// time there. It defaults to UTC.
+	Timezone string `json:"timezone"`
+
+	// Closures is the path of a CSV file listing the fields closed on some
+	// dates, with Venue, Field, From, To and Reason columns. It is optional.
+	Closures string `json:"closures"`
+
+	// Venue is the single venue of a version 1 config. It is moved into
+	// Venues when the config is loaded.
+	Venue VenueConfig `json:"venue"`
+
+	// Columns maps a column name to the other header names it may appear
+	// under in the input files, e.g. "Location": ["Field", "Diamond"].
+	Columns map[string][]string `json:"columns"`
+
+	// Formats lists the accepted date and time layouts, in Go's reference
+	// time notation. Either list may be left out to use the defaults.
+	Formats FormatsConfig `json:"formats"`
+
+	// Slots lists the time slots each game day is expected to have. Days in
+	// the season with slots are scheduled even when no games are booked.
+	Slots SlotsConfig `json:"slots"`
+
+	// Renderers names the outputs to write, e.g. ["excel", "csv", "ics"].
+	// When it is left out the workbooks and CSVs are written.
+	Renderers []string `json:"renderers"`
+
+	startDate time.Time
+	endDate   time.Time
+	slots     slotTemplates
+	location  *time.Location
+}
+
+// SeasonConfig is the window used to number the weeks on each sheet.
+type SeasonConfig struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// InputsConfig says where the division schedules are read from. When
+// Divisions is empty every .csv, .xlsx and .ics file in Dir is read.
+type InputsConfig struct {
+	Dir       string           `json:"dir"`
+	Divisions []DivisionConfig `json:"divisions"`
+}
+
+// OutputConfig says where the output folders are created. Dir defaults to
+// the working directory.
+type OutputConfig struct {
+	Dir string `json:"dir"`
+}
+
+// DivisionConfig is one manifest entry. Label is put in front of the team
+// names; when it is empty the file name is used. A Division column in the
+// file wins over both.
+type DivisionConfig struct {
+	File  string `json:"file"`
+	Label string `json:"label"`
+}
+
+// FormatsConfig holds the date and time layouts accepted in input files.
+type FormatsConfig struct {
+	Date []string `json:"date"`
+	Time []string `json:"time"`
+}
+
+// StyleConfig holds the workbook colors.
+type StyleConfig struct {
+	Background string `json:"background"`
+}
+
+// PageConfig holds the print layout of each workbook.
+type PageConfig struct {
+	Size        int           `json:"size"`
+	Orientation string        `json:"orientation"`
+	Margins     MarginsConfig `json:"margins"`
+}
+
+// MarginsConfig holds the page margins in inches.
+type MarginsConfig struct {
+	Top    float64 `json:"top"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+	Right  float64 `json:"right"`
+}
+
+// requiredKeys lists every key that must be present in a season config.
+var requiredKeys = []string{
+	"version",
+	"season.start",
+	"season.end",
+	"style.background",
+	"page.size",
+	"page.orientation",
+	"page.margins.top",
+	"page.margins.bottom",
+	"page.margins.left",
+	"page.margins.right",
+	"inputs.dir",
+}
+
+// versionKeys lists the keys required by each config version on top of
+// requiredKeys.
+var versionKeys = map[int][]string{
+	1: {"venue.name"},
+	2: {"venues"},
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// LoadConfig reads and validates the season config at path.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	var keys map[string]interface{}
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	version, _ := keys["version"].(float64)
+	for _, key := range append(append([]string{}, requiredKeys...), versionKeys[int(version)]...) {
+		if !hasKey(keys, key) {
+			return nil, fmt.Errorf("config %s: missing required key %q", path, key)
+		}
+	}
+
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// hasKey reports whether the dotted key path exists in the decoded JSON.
+func hasKey(m map[string]interface{}, key string) bool {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		v, ok := m[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		if m, ok = v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+func (c *Config) validate() error {
+	if c.Version < 1 || c.Version > configVersion {
+		return fmt.Errorf("unsupported version %d (expected 1 to %d)", c.Version, configVersion)
+	}
+
+	var err error
+	if c.startDate, err = time.Parse(DateFormat, c.Season.Start); err != nil {
+		return fmt.Errorf("season.start: %q is not a %s date", c.Season.Start, DateFormat)
+	}
+	if c.endDate, err = time.Parse(DateFormat, c.Season.End); err != nil {
+		return fmt.Errorf("season.end: %q is not a %s date", c.Season.End, DateFormat)
+	}
+	if c.endDate.Before(c.startDate) {
+		return fmt.Errorf("season.end %s is before season.start %s", c.Season.End, c.Season.Start)
+	}
+
+	if c.location, err = time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("timezone: %q is not a known time zone", c.Timezone)
+	}
+
+	if err := c.validateVenues(); err != nil {
+		return err
+	}
+	if err := c.validateFieldSizes(); err != nil {
+		return err
+	}
+	if !hexColor.MatchString(c.Style.Background) {
+		return fmt.Errorf("style.background: %q is not a #RRGGBB color", c.Style.Background)
+	}
+
+	if c.Page.Size < 1 {
+		return fmt.Errorf("page.size must be a positive excel paper size code")
+	}
+	if c.Page.Orientation != "portrait" && c.Page.Orientation != "landscape" {
+		return fmt.Errorf("page.orientation must be \"portrait\" or \"landscape\", got %q", c.Page.Orientation)
+	}
+	m := c.Page.Margins
+	if m.Top < 0 || m.Bottom < 0 || m.Left < 0 || m.Right < 0 {
+		return fmt.Errorf("page.margins must not be negative")
+	}
+
+	if strings.TrimSpace(c.Inputs.Dir) == "" {
+		return fmt.Errorf("inputs.dir must not be empty")
+	}
+	for i, d := range c.Inputs.Divisions {
+		if strings.TrimSpace(d.File) == "" {
+			return fmt.Errorf("inputs.divisions[%d].file must not be empty", i)
+		}
+	}
+
+	for _, layout := range append(append([]string{}, c.Formats.Date...), c.Formats.Time...) {
+		if strings.TrimSpace(layout) == "" {
+			return fmt.Errorf("formats: layouts must not be empty")
+		}
+	}
+
+	if err := c.parseSlots(); err != nil {
+		return err
+	}
+
+	for _, name := range c.Renderers {
+		if _, ok := renderers[name]; !ok {
+			return fmt.Errorf("renderers: %w", unknownRenderer(name))
+		}
+	}
+
+	for name, aliases := range c.Columns {
+		if !isKnownColumn(name) {
+			return fmt.Errorf("columns: unknown column %q (want one of %s, %s)", name, strings.Join(coreColumns, ", "), strings.Join(optionalColumns, ", "))
+		}
+		for _, alias := range aliases {
+			if strings.TrimSpace(alias) == "" {
+				return fmt.Errorf("columns.%s: aliases must not be empty", name)
+			}
+		}
+	}
+	return nil
+}
+
+// defaultRenderers are the outputs written when the config names none.
+var defaultRenderers = []string{"excel", "csv"}
+
+// renderers returns the names of the enabled renderers.
+func (c *Config) renderers() []string {
+	if len(c.Renderers) == 0 {
+		return defaultRenderers
+	}
+	return c.Renderers
+}
@@ -0,0 +1,71 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteCSV writes games to filename, with an empty row after each time slot.
+func (s *Schedule) WriteCSV(filename string, games []Game) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return s.RenderCSV(w, games)
+	})
+}
+
+// RenderCSV writes games as CSV to w, with an empty row after each time slot.
+func (s *Schedule) RenderCSV(w io.Writer, games []Game) error {
+	writer := csv.NewWriter(w)
+
+	// Write headers
+	headers := s.CSVColumns()
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for i, g := range games {
+		if err := writer.Write(GameToRecord(g, headers)); err != nil {
+			return err
+		}
+		if i == len(games)-1 || !games[i+1].Start.Equal(g.Start) {
+			if err := writer.Write(make([]string, len(headers))); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteFile renders into a temporary file next to filename and renames it
+// over filename once render succeeds, so a reader never sees a half-written
+// file. A render that returns Warnings still replaces filename.
+func WriteFile(filename string, render func(w io.Writer) error) error {
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+TempFileSuffix+"*")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	defer os.Remove(tmp)
+
+	err = render(file)
+	var warnings Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		file.Close()
+		return err
+	}
+	if cerr := file.Close(); cerr != nil {
+		return cerr
+	}
+	if cerr := os.Chmod(tmp, 0o644); cerr != nil {
+		return cerr
+	}
+	if rerr := os.Rename(tmp, filename); rerr != nil {
+		return rerr
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	failed := errors.New("render failed")
+	tests := []struct {
+		name     string
+		existing string // content of filename before the write, if any
+		dir      bool   // filename is a directory, so the rename fails
+		missing  bool   // the folder of filename does not exist
+		render   func(w io.Writer) error
+		want     string // content of filename after the write
+		wantErr  func(error) bool
+	}{
+		{
+			name:   "new file",
+			render: func(w io.Writer) error { _, err := io.WriteString(w, "new"); return err },
+			want:   "new",
+		},
+		{
+			name:     "replaces the old file",
+			existing: "old",
+			render:   func(w io.Writer) error { _, err := io.WriteString(w, "new"); return err },
+			want:     "new",
+		},
+		{
+			name:     "render error keeps the old file",
+			existing: "old",
+			render: func(w io.Writer) error {
+				io.WriteString(w, "half")
+				return failed
+			},
+			want:    "old",
+			wantErr: func(err error) bool { return errors.Is(err, failed) },
+		},
+		{
+			name:     "warnings still replace the file",
+			existing: "old",
+			render: func(w io.Writer) error {
+				io.WriteString(w, "new")
+				return Warnings{failed}
+			},
+			want:    "new",
+			wantErr: func(err error) bool { var w Warnings; return errors.As(err, &w) },
+		},
+		{
+			name:    "rename onto a directory fails",
+			dir:     true,
+			render:  func(w io.Writer) error { _, err := io.WriteString(w, "new"); return err },
+			wantErr: func(err error) bool { return err != nil },
+		},
+		{
+			name:    "missing folder",
+			missing: true,
+			render:  func(w io.Writer) error { return nil },
+			wantErr: func(err error) bool { return errors.Is(err, os.ErrNotExist) },
+		},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "sorted_schedule_1-4-2025.csv")
+		switch {
+		case tt.existing != "":
+			if err := os.WriteFile(filename, []byte(tt.existing), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		case tt.dir:
+			if err := os.Mkdir(filename, 0o755); err != nil {
+				t.Fatal(err)
+			}
+		case tt.missing:
+			filename = filepath.Join(dir, "missing", "sorted_schedule_1-4-2025.csv")
+		}
+
+		err := WriteFile(filename, tt.render)
+		if tt.wantErr == nil && err != nil {
+			t.Errorf("%s: WriteFile: %v", tt.name, err)
+		}
+		if tt.wantErr != nil && !tt.wantErr(err) {
+			t.Errorf("%s: WriteFile returned %v", tt.name, err)
+		}
+
+		if tt.want != "" {
+			got, err := os.ReadFile(filename)
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			} else if string(got) != tt.want {
+				t.Errorf("%s: file holds %q, want %q", tt.name, got, tt.want)
+			}
+		}
+		if tt.want != "" && tt.want != tt.existing {
+			if info, err := os.Stat(filename); err == nil && info.Mode().Perm() != 0o644 {
+				t.Errorf("%s: file mode = %v, want 0644", tt.name, info.Mode().Perm())
+			}
+		}
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if e.Name() != filepath.Base(filename) {
+				t.Errorf("%s: %s left behind", tt.name, e.Name())
+			}
+		}
+	}
+}
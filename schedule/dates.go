@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFormat is how dates are written in every output, and the format of the
+// season window in the config.
+const DateFormat string = "1/2/2006"
+
+// outputTimeFormat is how start times are written in every output.
+const outputTimeFormat string = "3:04 PM"
+
+// defaultDateFormats and defaultTimeFormats are the layouts accepted in the
+// input files when the config does not list its own.
+var (
+	defaultDateFormats = []string{"1/2/2006", "2006-01-02", "1-2-2006", "1/2/06"}
+	defaultTimeFormats = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM", "3 PM", "3PM"}
+)
+
+func (c *Config) dateFormats() []string {
+	if len(c.Formats.Date) > 0 {
+		return c.Formats.Date
+	}
+	return defaultDateFormats
+}
+
+func (c *Config) timeFormats() []string {
+	if len(c.Formats.Time) > 0 {
+		return c.Formats.Time
+	}
+	return defaultTimeFormats
+}
+
+// ParseDate parses s with the first accepted date layout that fits.
+func (c *Config) ParseDate(s string) (time.Time, error) {
+	if t, ok := parseAny(c.dateFormats(), s); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("not a date in any of the formats %s", strings.Join(c.dateFormats(), ", "))
+}
+
+// ParseTime parses s with the first accepted time layout that fits.
+func (c *Config) ParseTime(s string) (time.Time, error) {
+	if t, ok := parseAny(c.timeFormats(), s); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("not a time in any of the formats %s", strings.Join(c.timeFormats(), ", "))
+}
+
+// parseAny tries every layout against s as written and in upper and lower
+// case, so "3pm" and "3PM" are both accepted whichever way the layout is.
+func parseAny(layouts []string, s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		for _, v := range []string{s, strings.ToUpper(s), strings.ToLower(s)} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,69 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		formats []string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{nil, "1/4/2025", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{nil, "2025-01-04", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{nil, "1-4-2025", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{nil, "1/4/25", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{nil, " 12/31/2025 ", time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), false},
+		{nil, "4.1.2025", time.Time{}, true},
+		{nil, "", time.Time{}, true},
+		{[]string{"02.01.2006"}, "04.01.2025", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{[]string{"02.01.2006"}, "1/4/2025", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		c := &Config{Formats: FormatsConfig{Date: tt.formats}}
+		got, err := c.ParseDate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDate(%q) with %v: error = %v, want error %v", tt.in, tt.formats, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDate(%q) with %v = %v, want %v", tt.in, tt.formats, got, tt.want)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		formats []string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{nil, "9:00", "09:00", false},
+		{nil, "15:30", "15:30", false},
+		{nil, "09:00:00", "09:00", false},
+		{nil, "3:30 PM", "15:30", false},
+		{nil, "3:30pm", "15:30", false},
+		{nil, "3 PM", "15:00", false},
+		{nil, "3pm", "15:00", false},
+		{nil, "12:00 AM", "00:00", false},
+		{nil, "25:00", "", true},
+		{nil, "noon", "", true},
+		{[]string{"15h04"}, "9h30", "09:30", false},
+		{[]string{"15h04"}, "9:30", "", true},
+	}
+	for _, tt := range tests {
+		c := &Config{Formats: FormatsConfig{Time: tt.formats}}
+		got, err := c.ParseTime(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) with %v: error = %v, want error %v", tt.in, tt.formats, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Format("15:04") != tt.want {
+			t.Errorf("ParseTime(%q) with %v = %s, want %s", tt.in, tt.formats, got.Format("15:04"), tt.want)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// getWeekNumber returns the week number for a given date, starting on Monday
+func getWeekNumber(startDate time.Time, daysToAdd int) int {
+	newDate := startDate.AddDate(0, 0, daysToAdd)
+	_, week := newDate.ISOWeek()
+	return week
+}
+
+// calculateWeekNumber takes start date, end date, and current date, and returns the week number
+func calculateWeekNumber(startDate, endDate, currentDate time.Time) (int, error) {
+	if currentDate.Before(startDate) || currentDate.After(endDate) {
+		return 0, fmt.Errorf("current date is out of range")
+	}
+
+	daysSinceStart := int(currentDate.Sub(startDate).Hours() / 24)
+	weekNumber := getWeekNumber(startDate, daysSinceStart)
+	return weekNumber, nil
+}
+
+// weekNumber returns the week number printed on the sheet for day.
+func (s *Schedule) weekNumber(day time.Time) (int, error) {
+	return calculateWeekNumber(s.Config.startDate, s.Config.endDate, day)
+}
+
+// The logos placed at the top of every sheet.
+const (
+	flagLogoPath     = "./images/FlagLogo_x0.5.png"
+	footballLogoPath = "./images/PlayFootball@0.25x.png"
+)
+
+// excelAssets are the logos and cell styles every workbook uses. They are
+// loaded once per schedule and only read afterwards, so workbooks can be
+// rendered concurrently.
+type excelAssets struct {
+	flagLogo     []byte
+	footballLogo []byte
+
+	background, border, week, date, location, rowTitle *excelize.Style
+}
+
+// workbookAssets loads the workbook assets the first time it is called.
+func (s *Schedule) workbookAssets() (*excelAssets, error) {
+	s.assetsOnce.Do(func() {
+		s.assets, s.assetsErr = loadExcelAssets(s.Config.Style.Background)
+	})
+	return s.assets, s.assetsErr
+}
+
+func loadExcelAssets(bg string) (*excelAssets, error) {
+	a := &excelAssets{}
+	var err error
+	if a.flagLogo, err = os.ReadFile(flagLogoPath); err != nil {
+		return nil, err
+	}
+	if a.footballLogo, err = os.ReadFile(footballLogoPath); err != nil {
+		return nil, err
+	}
+
+	a.background = &excelize.Style{Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{bg}}}
+	a.border = &excelize.Style{
+		Border: []excelize.Border{
+			{Type: "bottom", Color: "000000", Style: 1},
+			{Type: "left", Color: "000000", Style: 1},
+			{Type: "right", Color: "000000", Style: 1},
+			{Type: "top", Color: "000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+		Font: &excelize.Font{
+			Size:  14,
+			Color: "000000",
+		},
+	}
+	a.week = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{bg}},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "top",
+		},
+		Font: &excelize.Font{
+			Bold:  true,
+			Size:  26,
+			Color: "#FFFFFF",
+		},
+	}
+	a.date = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{bg}},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+		},
+		Font: &excelize.Font{
+			Size:  16,
+			Color: "#FFFFFF",
+		},
+	}
+	a.location = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{bg}},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+		Font: &excelize.Font{
+			Size:  12,
+			Color: "#FFFFFF",
+		},
+	}
+	a.rowTitle = &excelize.Style{
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{bg}},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+		Font: &excelize.Font{
+			Size:  12,
+			Color: "#FFFFFF",
+		},
+	}
+	return a, nil
+}
+
+// imageContentTypes are the image types excelize declares in
+// [Content_Types].xml once a picture is added, in a fixed order.
+var imageContentTypes = []string{
+	"bmp", "image/bmp",
+	"emf", "image/x-emf",
+	"emz", "image/x-emz",
+	"gif", "image/gif",
+	"jpeg", "image/jpeg",
+	"png", "image/png",
+	"svg", "image/svg",
+	"tiff", "image/tiff",
+	"wmf", "image/x-wmf",
+	"wmz", "image/x-wmz",
+}
+
+// seedImageContentTypes declares the image types up front. excelize adds
+// the missing ones by ranging over a map, which would give the workbook's
+// [Content_Types].xml, and so its bytes, a different order on every run.
+func seedImageContentTypes(f *excelize.File) {
+	const name = "[Content_Types].xml"
+	raw, ok := f.Pkg.Load(name)
+	if !ok {
+		return
+	}
+	var defaults strings.Builder
+	for i := 0; i < len(imageContentTypes); i += 2 {
+		fmt.Fprintf(&defaults, `<Default Extension="%s" ContentType="%s"/>`, imageContentTypes[i], imageContentTypes[i+1])
+	}
+	seeded := strings.Replace(string(raw.([]byte)), "</Types>", defaults.String()+"</Types>", 1)
+	f.Pkg.Store(name, []byte(seeded))
+	// Drop the parsed copy so it is read again from the seeded XML.
+	f.ContentTypes = nil
+}
+
+// WriteExcel renders one day of games, sorted by time, to a workbook.
+func (s *Schedule) WriteExcel(filename string, games []Game) error {
+	return WriteFile(filename, func(w io.Writer) error {
+		return s.RenderExcel(w, games)
+	})
+}
+
+// RenderExcel renders one day of games, sorted by time, as a workbook
+// written to w, with a sheet for each venue. Each time slot is followed by a
+// thin colored separator row. Layout and style problems do not stop the
+// workbook from being written; they are returned together as Warnings.
+func (s *Schedule) RenderExcel(w io.Writer, games []Game) (err error) {
+	if len(games) == 0 {
+		return fmt.Errorf("no games to render")
+	}
+	assets, err := s.workbookAssets()
+	if err != nil {
+		return err
+	}
+	f := excelize.NewFile()
+	seedImageContentTypes(f)
+
+	var warnings Warnings
+	warn := func(err error) {
+		if err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+	defer func() {
+		warn(f.Close())
+		if err == nil && len(warnings) > 0 {
+			err = warnings
+		}
+	}()
+
+	warn(f.SetDefaultFont("Arial Rounded MT Bold"))
+
+	currentDate := games[0].Day()
+
+	weekNumber, err := s.weekNumber(currentDate)
+	if err != nil {
+		return err
+	}
+
+	s.logger().Debug("week number", "date", currentDate.Format(DateFormat), "week", weekNumber)
+
+	styles := sheetStyles{}
+	styleOf := func(style *excelize.Style) int {
+		id, err := f.NewStyle(style)
+		warn(err)
+		return id
+	}
+	styles.background = styleOf(assets.background)
+	styles.border = styleOf(assets.border)
+	styles.week = styleOf(assets.week)
+	styles.date = styleOf(assets.date)
+	styles.location = styleOf(assets.location)
+	styles.rowTitle = styleOf(assets.rowTitle)
+
+	used := make(map[string]bool)
+	for i, vg := range s.groupByVenue(games) {
+		sheet := sheetName(vg.venue.Name, used)
+		if i == 0 {
+			err = f.SetSheetName("Sheet1", sheet)
+		} else {
+			_, err = f.NewSheet(sheet)
+		}
+		if err != nil {
+			return fmt.Errorf("sheet %q: %w", sheet, err)
+		}
+		header := [3]string{
+			fmt.Sprintf("Week #%d", weekNumber),
+			currentDate.Format("January 2, 2006"),
+			vg.venue.Label(),
+		}
+		if err := s.layoutSheet(f, sheet, header, vg.games, assets, styles, warn); err != nil {
+			return err
+		}
+	}
+	return f.Write(w)
+}
+
+// sheetStyles are the style IDs of one workbook.
+type sheetStyles struct {
+	background, border, week, date, location, rowTitle int
+}
+
+// sheetName returns a valid sheet name for a venue that is not in used, and
+// adds it to used. Excel compares sheet names without case.
+func sheetName(venue string, used map[string]bool) string {
+	base := strings.Trim(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '-'
+		}
+		return r
+	}, venue), "' ")
+	if base == "" {
+		base = "Sheet"
+	}
+	name := truncateRunes(base, excelize.MaxSheetNameLength)
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		name = truncateRunes(base, excelize.MaxSheetNameLength-len(suffix)) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// layoutSheet writes the logos, the three header lines and the games of one
+// venue to sheet. Layout and style problems are passed to warn; the error is
+// for a workbook that cannot be built.
+func (s *Schedule) layoutSheet(f *excelize.File, sheet string, header [3]string, games []Game, assets *excelAssets, styles sheetStyles, warn func(error)) error {
+	columns := s.ExcelColumns()
+
+	// Columns run from B to lastCol, with a narrow colored edge column after.
+	lastCol, _ := excelize.ColumnNumberToName(1 + len(columns))
+	edgeCol, _ := excelize.ColumnNumberToName(2 + len(columns))
+
+	warn(f.SetPageLayout(sheet, &excelize.PageLayoutOptions{
+		Size:        &s.Config.Page.Size,
+		Orientation: &s.Config.Page.Orientation,
+	}))
+
+	warn(f.SetPageMargins(sheet, &excelize.PageLayoutMarginsOptions{
+		Left:   &s.Config.Page.Margins.Left,
+		Right:  &s.Config.Page.Margins.Right,
+		Top:    &s.Config.Page.Margins.Top,
+		Bottom: &s.Config.Page.Margins.Bottom,
+	}))
+	warn(f.MergeCell(sheet, "B1", "B3"))
+	warn(f.MergeCell(sheet, "E1", "F3"))
+	enable, disable := true, false
+
+	if err := f.AddPictureFromBytes(sheet, "B1", &excelize.Picture{
+		Extension: filepath.Ext(flagLogoPath),
+		File:      assets.flagLogo,
+		Format: &excelize.GraphicOptions{
+			PrintObject:     &enable,
+			Locked:          &disable,
+			OffsetX:         5,
+			OffsetY:         5,
+			ScaleX:          0.3,
+			ScaleY:          0.5,
+			AutoFit:         false,
+			LockAspectRatio: true,
+			Positioning:     "absolute",
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: %w", flagLogoPath, err)
+	}
+
+	if err := f.AddPictureFromBytes(sheet, "E1", &excelize.Picture{
+		Extension: filepath.Ext(footballLogoPath),
+		File:      assets.footballLogo,
+		Format: &excelize.GraphicOptions{
+			PrintObject:     &enable,
+			Locked:          &disable,
+			OffsetX:         50,
+			OffsetY:         10,
+			ScaleX:          0.4,
+			ScaleY:          0.4,
+			AutoFit:         false,
+			LockAspectRatio: true,
+			Positioning:     "absolute",
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: %w", footballLogoPath, err)
+	}
+
+	var cellErrs []error
+	setCell := func(cell string, value interface{}) {
+		if err := f.SetCellValue(sheet, cell, value); err != nil {
+			cellErrs = append(cellErrs, err)
+		}
+	}
+	setCell("C1", header[0])
+	setCell("C2", header[1])
+	setCell("C3", header[2])
+	for c, name := range columns {
+		cell, _ := excelize.CoordinatesToCellName(2+c, 4)
+		setCell(cell, strings.ToUpper(name))
+	}
+	// Write each time slot as a block of rows followed by a separator row.
+	startRow := 5
+	rowNumber := startRow
+	var blocks [][2]int
+	for i, g := range games {
+		if i == 0 || !g.Start.Equal(games[i-1].Start) {
+			blocks = append(blocks, [2]int{rowNumber, rowNumber})
+		}
+		for c, value := range GameToRecord(g, columns) {
+			cell, _ := excelize.CoordinatesToCellName(2+c, rowNumber)
+			setCell(cell, value)
+		}
+		blocks[len(blocks)-1][1] = rowNumber
+		rowNumber++
+		if i == len(games)-1 || !games[i+1].Start.Equal(g.Start) {
+			rowNumber++
+		}
+	}
+	lastRow := rowNumber - 1
+
+	warn(f.SetColWidth(sheet, "A", "A", 0.8))
+	warn(f.SetColWidth(sheet, "B", "C", 34))
+	warn(f.SetColWidth(sheet, "D", "D", 15))
+	warn(f.SetColWidth(sheet, "E", "E", 12))
+	warn(f.SetColWidth(sheet, "F", lastCol, 15))
+	warn(f.SetColWidth(sheet, edgeCol, edgeCol, 0.8))
+	warn(f.SetRowHeight(sheet, 1, 28))
+	warn(f.SetRowHeight(sheet, 2, 28))
+	warn(f.SetRowHeight(sheet, 3, 24))
+	warn(f.SetRowHeight(sheet, 4, 24))
+	for _, block := range blocks {
+		for r := block[0]; r <= block[1]; r++ {
+			warn(f.SetRowHeight(sheet, r, 26))
+		}
+		warn(f.SetRowHeight(sheet, block[1]+1, 5))
+	}
+	warn(f.SetCellStyle(sheet, "B1", lastCol+"4", styles.background))
+	warn(f.SetCellStyle(sheet, "A1", fmt.Sprintf("A%d", lastRow), styles.background))
+	warn(f.SetCellStyle(sheet, edgeCol+"1", fmt.Sprintf("%s%d", edgeCol, lastRow), styles.background))
+	for _, block := range blocks {
+		sep := block[1] + 1
+		warn(f.SetCellStyle(sheet, fmt.Sprintf("B%d", sep), fmt.Sprintf("%s%d", lastCol, sep), styles.background))
+	}
+	for _, block := range blocks {
+		warn(f.SetCellStyle(sheet, fmt.Sprintf("B%d", block[0]), fmt.Sprintf("%s%d", lastCol, block[1]), styles.border))
+	}
+	warn(f.SetCellStyle(sheet, "C1", "C1", styles.week))
+	warn(f.SetCellStyle(sheet, "C2", "C2", styles.date))
+	warn(f.SetCellStyle(sheet, "C3", "C3", styles.location))
+	warn(f.SetCellStyle(sheet, "B4", lastCol+"4", styles.rowTitle))
+
+	return errors.Join(cellErrs...)
+}
@@ -0,0 +1,109 @@
+package schedule
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// icsRenderer writes the whole season as one iCalendar file with an event
+// per game. Open fields are left out.
+type icsRenderer struct{ s *Schedule }
+
+func (icsRenderer) Name() string { return "ics" }
+func (icsRenderer) Ext() string  { return ".ics" }
+func (icsRenderer) Scope() Scope { return PerSeason }
+
+func (r icsRenderer) Render(w io.Writer, days []Day) error {
+	var games []Game
+	for _, d := range days {
+		games = append(games, d.Games...)
+	}
+	return r.s.RenderICS(w, games)
+}
+
+// icsTimeFormat is a floating DTSTART: the wall-clock time at the venue.
+const icsTimeFormat = "20060102T150405"
+
+// RenderICS writes games as an iCalendar file to w. Each event's UID is
+// derived from the game itself, so re-importing an updated calendar replaces
+// events instead of duplicating them.
+func (s *Schedule) RenderICS(w io.Writer, games []Game) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeICSLine(bw, name+":"+value)
+	}
+
+	// DTSTAMP is fixed to the season start so the file only changes when
+	// the games do.
+	stamp := s.Config.startDate.Format(icsTimeFormat) + "Z"
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//scheduleTemplate//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeICSText(venueNames(s.Config.Venues)))
+	for _, g := range games {
+		if g.IsOpen() || g.Closed {
+			continue
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", gameUID(g))
+		line("DTSTAMP", stamp)
+		line("DTSTART", g.Start.Format(icsTimeFormat))
+		line("SUMMARY", escapeICSText(g.HomeLabel()+" vs "+g.AwayLabel()))
+		line("LOCATION", escapeICSText(s.icsLocation(g)))
+		if g.Division != "" {
+			line("CATEGORIES", escapeICSText(g.Division))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// icsLocation returns a game's LOCATION, "<venue> - <field>, <address>",
+// which reads back as the same venue and field.
+func (s *Schedule) icsLocation(g Game) string {
+	location := g.Location
+	if g.Venue != "" {
+		location = g.Venue + venueSeparator + location
+	}
+	if v, ok := s.Config.VenueNamed(g.Venue); ok && v.Address != "" {
+		location += ", " + v.Address
+	}
+	return location
+}
+
+// gameUID returns a UID that stays the same as long as the game's date,
+// time, field and teams do.
+func gameUID(g Game) string {
+	sum := sha1.Sum([]byte(strings.Join([]string{
+		g.Start.Format(icsTimeFormat), g.Venue, g.Location, g.Division, g.Home, g.Away,
+	}, "\x00")))
+	return fmt.Sprintf("%x@scheduleTemplate", sum[:10])
+}
+
+// writeICSLine writes one content line, folded at 75 octets as RFC 5545
+// requires.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts toward its length.
+		limit = 74
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,138 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// validateFieldSizes checks that every division rule names field sizes that
+// some venue has.
+func (c *Config) validateFieldSizes() error {
+	for division, allowed := range c.FieldSizes {
+		if strings.TrimSpace(division) == "" {
+			return fmt.Errorf("fieldSizes: division names must not be empty")
+		}
+		if len(allowed) == 0 {
+			return fmt.Errorf("fieldSizes.%s must list at least one size", division)
+		}
+		for _, size := range allowed {
+			if !c.HasSize(size) {
+				return fmt.Errorf("fieldSizes.%s: no venue field has size %q", division, size)
+			}
+		}
+	}
+	return nil
+}
+
+// Sizes returns the field sizes of the venue fields, in the order they are
+// first listed.
+func (c *Config) Sizes() []string {
+	seen := make(map[string]bool)
+	var sizes []string
+	for _, v := range c.Venues {
+		for _, f := range v.FieldList() {
+			if f.Size != "" && !seen[strings.ToLower(f.Size)] {
+				seen[strings.ToLower(f.Size)] = true
+				sizes = append(sizes, f.Size)
+			}
+		}
+	}
+	return sizes
+}
+
+// HasSize reports whether some venue field has size, in any case.
+func (c *Config) HasSize(size string) bool {
+	for _, s := range c.Sizes() {
+		if strings.EqualFold(s, strings.TrimSpace(size)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sizesFor returns the field sizes division may play on, or nil when it may
+// play on any field.
+func (c *Config) sizesFor(division string) []string {
+	for name, sizes := range c.FieldSizes {
+		if strings.EqualFold(name, division) {
+			return sizes
+		}
+	}
+	return nil
+}
+
+// Allows reports whether division may play on field. Divisions without a
+// rule in FieldSizes may play on any field.
+func (c *Config) Allows(division string, field FieldConfig) bool {
+	sizes := c.sizesFor(division)
+	if sizes == nil {
+		return true
+	}
+	for _, size := range sizes {
+		if strings.EqualFold(strings.TrimSpace(size), field.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+// divisions returns the divisions that have games or a field size rule, in
+// age order.
+func (s *Schedule) divisions() []string {
+	seen := make(map[string]bool)
+	var divisions []string
+	add := func(d string) {
+		if d != "" && !seen[strings.ToLower(d)] {
+			seen[strings.ToLower(d)] = true
+			divisions = append(divisions, d)
+		}
+	}
+	for _, g := range s.Games {
+		add(g.Division)
+	}
+	for d := range s.Config.FieldSizes {
+		add(d)
+	}
+	sort.Slice(divisions, func(i, j int) bool { return lessDivision(divisions[i], divisions[j]) })
+	return divisions
+}
+
+// lessDivision orders divisions by the age they start with, so 7U comes
+// before 10U, and then by name.
+func lessDivision(a, b string) bool {
+	na, _ := strconv.Atoi(leadingDigits(a))
+	nb, _ := strconv.Atoi(leadingDigits(b))
+	if na != nb {
+		return na < nb
+	}
+	return a < b
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// eligibleDivisions returns the divisions that may book field, or nil when
+// every division may.
+func (s *Schedule) eligibleDivisions(field FieldConfig) []string {
+	if len(s.Config.FieldSizes) == 0 {
+		return nil
+	}
+	all := s.divisions()
+	var eligible []string
+	for _, d := range all {
+		if s.Config.Allows(d, field) {
+			eligible = append(eligible, d)
+		}
+	}
+	if len(eligible) == len(all) {
+		return nil
+	}
+	return eligible
+}
@@ -0,0 +1,109 @@
+package schedule
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateFieldSizes(t *testing.T) {
+	tests := []struct {
+		sizes   map[string][]string
+		wantErr string
+	}{
+		{map[string][]string{"15U": {"full"}, "7U": {"Short", " full "}}, ""},
+		{map[string][]string{"15U": {"ful"}}, `fieldSizes.15U: no venue field has size "ful"`},
+		{map[string][]string{"15U": {}}, "fieldSizes.15U must list at least one size"},
+		{map[string][]string{" ": {"full"}}, "fieldSizes: division names must not be empty"},
+	}
+	for _, tt := range tests {
+		c := testConfig(t, nil)
+		c.FieldSizes = tt.sizes
+		err := c.validateFieldSizes()
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("validateFieldSizes(%v) = %q, want %q", tt.sizes, got, tt.wantErr)
+		}
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestAllows(t *testing.T) {
+	c := testConfig(t, func(c *Config) { c.FieldSizes = map[string][]string{"15U": {"full"}, "7u": {"short"}} })
+	full, short := FieldConfig{Number: 1, Size: "full"}, FieldConfig{Number: 2, Size: "short"}
+	tests := []struct {
+		division string
+		field    FieldConfig
+		want     bool
+	}{
+		{"15U", full, true},
+		{"15U", short, false},
+		{"15u", short, false},
+		{"7U", short, true},
+		{"7U", full, false},
+		{"10U", short, true},
+		{"", full, true},
+		{"15U", FieldConfig{Number: 3}, false},
+	}
+	for _, tt := range tests {
+		if got := c.Allows(tt.division, tt.field); got != tt.want {
+			t.Errorf("Allows(%q, %q field) = %v, want %v", tt.division, tt.field.Size, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGameFieldSize(t *testing.T) {
+	c := testConfig(t, nil)
+	tests := []struct {
+		division, location string
+		want               string
+	}{
+		{"15U", "North", ""},
+		{"15U", "Lakeside - Main", ""},
+		{"15U", "South", "15U plays on full fields, South is short"},
+		{"7U", "South", ""},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c}
+		s.checkGame(testGame(t, c, 4, "9:00", "", tt.location, tt.division, "Red", "Blue"))
+		var got []string
+		for _, issue := range s.Issues {
+			got = append(got, issue.Message)
+		}
+		if strings.Join(got, "; ") != tt.want {
+			t.Errorf("%s on %s: issues %q, want %q", tt.division, tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestFillDayDivisions(t *testing.T) {
+	c := testConfig(t, nil)
+	games := []Game{
+		testGame(t, c, 4, "9:00", "", "North", "15U", "Red", "Blue"),
+		testGame(t, c, 4, "10:30", "", "South", "7U", "Gold", "Green"),
+	}
+	s := &Schedule{Config: c, Games: games}
+	want := []string{
+		"09:00 Ballpark North: 15U Red vs 15U Blue",
+		"09:00 Ballpark South: open 7U,10U",
+		"10:30 Ballpark North: open",
+		"10:30 Ballpark South: 7U Gold vs 7U Green",
+	}
+	if got := filledDay(s, 4, games); !reflect.DeepEqual(got, want) {
+		t.Errorf("open fields:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestDivisionsInAgeOrder(t *testing.T) {
+	c := testConfig(t, func(c *Config) { c.FieldSizes = map[string][]string{"15U": {"full"}} })
+	s := &Schedule{Config: c, Games: []Game{{Division: "10U"}, {Division: "7U"}, {Division: "Adult"}, {Division: "10u"}, {Division: "7U Girls"}}}
+	want := []string{"Adult", "7U", "7U Girls", "10U", "15U"}
+	if got := s.divisions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("divisions() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,206 @@
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenField is the team name shown on both sides of an unbooked field.
+const OpenField string = "Open Field"
+
+// Game is one scheduled game, or an open field when Home and Away are both
+// OpenField.
+type Game struct {
+	// Start is the date and start time of the game.
+	Start time.Time
+
+	Division string
+	Home     string
+	Away     string
+
+	// Venue is the name of the venue the game is played at. Location is the
+	// name of the field, e.g. "Field #3", and Field its number. A game on a
+	// field that is not in the config keeps the venue and location as
+	// written and the number in the location, or 0.
+	Venue    string
+	Location string
+	Field    int
+
+	// Divisions lists, for an open field, the divisions that may book it.
+	// It is nil when every division may.
+	Divisions []string
+
+	// Closed marks the filler of a field that is closed on the day. Its
+	// teams are the ClosedLabel.
+	Closed bool
+
+	// Attrs holds the extra input columns, e.g. Notes or GameID.
+	Attrs map[string]string
+
+	// Source and Line locate the input row the game was read from.
+	Source string
+	Line   int
+}
+
+// Date returns the day the game is played on, formatted with DateFormat.
+func (g Game) Date() string {
+	return g.Start.Format(DateFormat)
+}
+
+// Day returns midnight at the start of the day the game is played on.
+func (g Game) Day() time.Time {
+	return time.Date(g.Start.Year(), g.Start.Month(), g.Start.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsOpen reports whether g is an unbooked field rather than a game.
+func (g Game) IsOpen() bool {
+	return g.Home == OpenField && g.Away == OpenField
+}
+
+// HomeLabel and AwayLabel return the team names as printed, prefixed with
+// the division.
+func (g Game) HomeLabel() string { return teamLabel(g.Division, g.Home) }
+func (g Game) AwayLabel() string { return teamLabel(g.Division, g.Away) }
+
+func teamLabel(division, team string) string {
+	if division == "" {
+		return team
+	}
+	return division + " " + team
+}
+
+var fieldNumber = regexp.MustCompile(`#\s*(\d+)`)
+
+// parseField returns the field number in a location such as "Field #3".
+func parseField(location string) int {
+	m := fieldNumber.FindStringSubmatch(location)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// newClosedField returns the filler shown for a closed field.
+func newClosedField(start time.Time, venue VenueConfig, field FieldConfig, reason string) Game {
+	g := newOpenField(start, venue, field)
+	g.Home, g.Away = ClosedLabel(reason), ClosedLabel(reason)
+	g.Closed = true
+	return g
+}
+
+// newOpenField returns the filler shown for an unbooked field.
+func newOpenField(start time.Time, venue VenueConfig, field FieldConfig) Game {
+	return Game{
+		Start:    start,
+		Home:     OpenField,
+		Away:     OpenField,
+		Venue:    venue.Name,
+		Location: field.Name,
+		Field:    field.Number,
+	}
+}
+
+// CSVColumns returns the columns of the output CSVs: the core columns, the
+// division, the venue when there is more than one and then the extra
+// columns.
+func (s *Schedule) CSVColumns() []string {
+	cols := append(append([]string{}, coreColumns...), divisionColumn)
+	if len(s.Config.Venues) > 1 {
+		cols = append(cols, venueColumn)
+	}
+	return append(cols, s.Columns...)
+}
+
+// ExcelColumns returns the columns printed in the workbooks. The division is
+// already part of the team names so it is not repeated.
+func (s *Schedule) ExcelColumns() []string {
+	return append(append([]string{}, coreColumns...), s.Columns...)
+}
+
+// GameToRecord returns g as a row with the given columns. Home and Away are
+// written with their division prefix, as they are printed.
+func GameToRecord(g Game, columns []string) []string {
+	record := make([]string, len(columns))
+	for i, name := range columns {
+		switch name {
+		case "Home":
+			record[i] = g.HomeLabel()
+		case "Away":
+			record[i] = g.AwayLabel()
+		case "Date":
+			record[i] = g.Date()
+		case "Time":
+			record[i] = g.Start.Format(outputTimeFormat)
+		case "Location":
+			record[i] = g.Location
+		case divisionColumn:
+			record[i] = g.Division
+			if g.IsOpen() {
+				record[i] = strings.Join(g.Divisions, ", ")
+			}
+		case venueColumn:
+			record[i] = g.Venue
+		default:
+			record[i] = g.Attrs[name]
+		}
+	}
+	return record
+}
+
+// GameFromRecord is the inverse of GameToRecord. The division prefix is
+// taken back off the team names, and open and closed fields are read back
+// as fillers.
+func (s *Schedule) GameFromRecord(header, record []string) (Game, error) {
+	values := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(record) {
+			values[name] = record[i]
+		}
+	}
+
+	date, err := s.Config.ParseDate(values["Date"])
+	if err != nil {
+		return Game{}, fmt.Errorf("Date %q: %w", values["Date"], err)
+	}
+	start, err := s.Config.ParseTime(values["Time"])
+	if err != nil {
+		return Game{}, fmt.Errorf("Time %q: %w", values["Time"], err)
+	}
+
+	g := Game{
+		Start:    combineDateTime(date, start),
+		Division: values[divisionColumn],
+		Attrs:    make(map[string]string),
+	}
+	s.Config.place(&g, values[venueColumn], values["Location"])
+	g.Home = strings.TrimPrefix(values["Home"], teamLabel(g.Division, ""))
+	g.Away = strings.TrimPrefix(values["Away"], teamLabel(g.Division, ""))
+	if g.IsOpen() {
+		for _, d := range strings.Split(g.Division, ",") {
+			if d = strings.TrimSpace(d); d != "" {
+				g.Divisions = append(g.Divisions, d)
+			}
+		}
+		g.Division = ""
+	}
+	if isClosedLabel(g.Home) && isClosedLabel(g.Away) {
+		g.Closed = true
+		g.Division = ""
+	}
+	for _, name := range header {
+		if !isKnownColumn(name) {
+			g.Attrs[name] = values[name]
+			s.addColumn(name)
+		}
+	}
+	return g, nil
+}
+
+// combineDateTime returns the day of date at the clock time of clock.
+func combineDateTime(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, time.UTC)
+}
@@ -0,0 +1,83 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGameRecordRoundTrip(t *testing.T) {
+	start := time.Date(2025, 1, 4, 9, 30, 0, 0, time.UTC)
+	oneVenue := &Config{Venues: []VenueConfig{{Name: "Ballpark"}}}
+	twoVenues := &Config{Venues: []VenueConfig{
+		{Name: "Ballpark"},
+		{Name: "Lakeside", Fields: []FieldConfig{{Number: 1, Name: "Main"}}},
+	}}
+	tests := []struct {
+		name    string
+		config  *Config
+		columns []string
+		game    Game
+	}{
+		{
+			name:    "game with extra columns",
+			config:  oneVenue,
+			columns: []string{"Notes", "GameID"},
+			game: Game{
+				Start: start, Division: "10U", Home: "Red", Away: "Blue",
+				Venue: "Ballpark", Location: "Field #3", Field: 3,
+				Attrs: map[string]string{"Notes": "bring, \"cones\"", "GameID": "17"},
+			},
+		},
+		{
+			name:   "game at the second venue",
+			config: twoVenues,
+			game: Game{
+				Start: start, Division: "12U", Home: "Gold", Away: "Green",
+				Venue: "Lakeside", Location: "Main", Field: 1,
+				Attrs: map[string]string{},
+			},
+		},
+		{
+			name:   "game without a division",
+			config: oneVenue,
+			game: Game{
+				Start: start, Home: "Red", Away: "Blue",
+				Venue: "Ballpark", Location: "Field #1", Field: 1,
+				Attrs: map[string]string{},
+			},
+		},
+		{
+			name:   "open field for some divisions",
+			config: twoVenues,
+			game: Game{
+				Start: start, Home: OpenField, Away: OpenField,
+				Venue: "Ballpark", Location: "Field #6", Field: 6,
+				Divisions: []string{"7U", "10U"},
+				Attrs:     map[string]string{},
+			},
+		},
+		{
+			name:   "open field for every division",
+			config: oneVenue,
+			game: Game{
+				Start: start, Home: OpenField, Away: OpenField,
+				Venue: "Ballpark", Location: "Field #2", Field: 2,
+				Attrs: map[string]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: tt.config, Columns: tt.columns}
+		header := s.CSVColumns()
+		record := GameToRecord(tt.game, header)
+		got, err := s.GameFromRecord(header, record)
+		if err != nil {
+			t.Errorf("%s: GameFromRecord(%q): %v", tt.name, record, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.game) {
+			t.Errorf("%s: round trip through %q\ngot  %+v\nwant %+v", tt.name, record, got, tt.game)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package schedule
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlRenderer writes each day as a standalone web page laid out like the
+// workbook: a header with the week, date and venue, then one table row per
+// game with a colored separator between time slots.
+type htmlRenderer struct{ s *Schedule }
+
+func (htmlRenderer) Name() string { return "html" }
+func (htmlRenderer) Ext() string  { return ".html" }
+func (htmlRenderer) Scope() Scope { return PerDay }
+
+func (r htmlRenderer) Render(w io.Writer, days []Day) error {
+	return r.s.RenderHTML(w, days[0].Games)
+}
+
+var htmlPage = template.Must(template.New("day").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Date}}</title>
+<style>
+body { font-family: "Arial Rounded MT Bold", Arial, sans-serif; margin: 0; }
+header, h2, thead th, tr.separator td { background: {{.Background}}; color: #FFFFFF; }
+header, h2 { text-align: center; padding: 8px; }
+header h1 { margin: 0; font-size: 26pt; }
+header p { margin: 4px 0; }
+h2 { margin: 0; font-size: 14pt; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #000000; text-align: center; padding: 6px; }
+td { font-size: 14pt; }
+tr.separator td { height: 5px; padding: 0; }
+</style>
+</head>
+<body>
+<header>
+<h1>Week #{{.Week}}</h1>
+<p class="date">{{.Date}}</p>
+</header>
+{{- range .Venues}}
+<section>
+<h2 class="venue">{{.Name}}</h2>
+<table>
+<thead>
+<tr>{{range $.Columns}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Blocks}}
+{{- range .}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+<tr class="separator"><td colspan="{{len $.Columns}}"></td></tr>
+{{- end}}
+</tbody>
+</table>
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML renders one day of games, sorted by time, as a web page
+// written to w, with a section for each venue.
+func (s *Schedule) RenderHTML(w io.Writer, games []Game) error {
+	if len(games) == 0 {
+		return fmt.Errorf("no games to render")
+	}
+	day := games[0].Day()
+	week, err := s.weekNumber(day)
+	if err != nil {
+		return err
+	}
+
+	columns := s.ExcelColumns()
+	titles := make([]string, len(columns))
+	for i, name := range columns {
+		titles[i] = strings.ToUpper(name)
+	}
+	type venueSection struct {
+		Name   string
+		Blocks [][][]string
+	}
+	var venues []venueSection
+	for _, vg := range s.groupByVenue(games) {
+		var blocks [][][]string
+		for i, g := range vg.games {
+			if i == 0 || !g.Start.Equal(vg.games[i-1].Start) {
+				blocks = append(blocks, nil)
+			}
+			blocks[len(blocks)-1] = append(blocks[len(blocks)-1], GameToRecord(g, columns))
+		}
+		venues = append(venues, venueSection{vg.venue.Label(), blocks})
+	}
+
+	return htmlPage.Execute(w, struct {
+		Week       int
+		Date       string
+		Background template.CSS
+		Columns    []string
+		Venues     []venueSection
+	}{
+		Week:       week,
+		Date:       day.Format("January 2, 2006"),
+		Background: template.CSS(s.Config.Style.Background),
+		Columns:    titles,
+		Venues:     venues,
+	})
+}
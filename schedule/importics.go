@@ -0,0 +1,222 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// summarySeparators split an event SUMMARY into its two teams. homeFirst
+// tells which side of the separator the home team is on.
+var summarySeparators = []struct {
+	re        *regexp.Regexp
+	homeFirst bool
+}{
+	{regexp.MustCompile(`(?i)\s+vs\.?\s+`), true},
+	{regexp.MustCompile(`(?i)\s+v\.?\s+`), true},
+	{regexp.MustCompile(`\s+@\s+`), false},
+	{regexp.MustCompile(`(?i)\s+at\s+`), false},
+}
+
+// icsProperty is one unfolded content line of a calendar.
+type icsProperty struct {
+	line   int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// readICS appends the VEVENTs in an iCalendar file to s.Games. SUMMARY
+// gives the teams ("Home vs Away" or "Away @ Home"), DTSTART the date and
+// time and LOCATION the field. Events that are not games, such as an
+// all-day picture day, are reported as warnings and skipped.
+func (s *Schedule) readICS(filename, label string) error {
+	props, err := s.readICSProperties(filename)
+	if err != nil {
+		return err
+	}
+
+	var event map[string]icsProperty
+	var begin int
+	for _, p := range props {
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT"):
+			event = make(map[string]icsProperty)
+			begin = p.line
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if event == nil {
+				continue
+			}
+			g, err := eventToGame(event, s.Config.location)
+			event = nil
+			if err != nil {
+				s.report(filename, begin, "VEVENT", SeverityWarning, "skipped: %v", err)
+				continue
+			}
+			g.Division = label
+			s.Config.place(&g, "", g.Location)
+			g.Source, g.Line = filename, begin
+			s.checkGame(g)
+			s.checkDate(g)
+			s.checkClosure(g)
+			s.Games = append(s.Games, g)
+		case event != nil:
+			if _, seen := event[p.name]; !seen {
+				event[p.name] = p
+			}
+		}
+	}
+	return nil
+}
+
+// eventToGame converts one VEVENT into a game played in the time zone loc.
+func eventToGame(event map[string]icsProperty, loc *time.Location) (Game, error) {
+	summary, ok := event["SUMMARY"]
+	if !ok {
+		return Game{}, fmt.Errorf("event has no SUMMARY")
+	}
+	home, away, err := splitSummary(summary.value)
+	if err != nil {
+		return Game{}, err
+	}
+
+	start, ok := event["DTSTART"]
+	if !ok {
+		return Game{}, fmt.Errorf("event %q has no DTSTART", summary.value)
+	}
+	if strings.EqualFold(start.params["VALUE"], "DATE") {
+		return Game{}, fmt.Errorf("event %q is all-day, a start time is needed", summary.value)
+	}
+	when, err := parseICSDateTime(start, loc)
+	if err != nil {
+		return Game{}, fmt.Errorf("event %q: %w", summary.value, err)
+	}
+
+	location := strings.TrimSpace(event["LOCATION"].value)
+	return Game{
+		Start:    combineDateTime(when, when),
+		Home:     home,
+		Away:     away,
+		Location: location,
+		Attrs:    make(map[string]string),
+	}, nil
+}
+
+// splitSummary returns the home and away teams named in an event summary.
+func splitSummary(summary string) (home, away string, err error) {
+	for _, sep := range summarySeparators {
+		parts := sep.re.Split(summary, 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			continue
+		}
+		first, second := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if sep.homeFirst {
+			return first, second, nil
+		}
+		return second, first, nil
+	}
+	return "", "", fmt.Errorf("summary %q does not name two teams (\"Home vs Away\" or \"Away @ Home\")", summary)
+}
+
+// parseICSDateTime parses a DTSTART value as the clock time in loc. UTC
+// times and times with a known TZID are converted to loc; floating times,
+// and TZIDs that cannot be loaded, are kept as the clock time they name.
+func parseICSDateTime(p icsProperty, loc *time.Location) (time.Time, error) {
+	var t time.Time
+	var err error
+	tz, tzErr := time.LoadLocation(p.params["TZID"])
+	switch {
+	case strings.HasSuffix(p.value, "Z"):
+		t, err = time.Parse("20060102T150405Z", p.value)
+	case p.params["TZID"] != "" && tzErr == nil:
+		t, err = time.ParseInLocation("20060102T150405", p.value, tz)
+	default:
+		t, err = time.Parse("20060102T150405", p.value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid DTSTART %q", p.value)
+		}
+		return t, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTSTART %q", p.value)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+}
+
+// readICSProperties reads the content lines of a calendar, unfolding
+// continuation lines and unescaping text values. Malformed lines are
+// reported and skipped.
+func (s *Schedule) readICSProperties(filename string) ([]icsProperty, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var props []icsProperty
+	var current strings.Builder
+	currentLine := 0
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		p, err := parseICSLine(current.String())
+		current.Reset()
+		if err != nil {
+			s.report(filename, currentLine, "", SeverityError, "%v", err)
+			return
+		}
+		p.line = currentLine
+		props = append(props, p)
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			current.WriteString(line[1:])
+			continue
+		}
+		flush()
+		current.WriteString(line)
+		currentLine = lineNo
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return props, nil
+}
+
+// parseICSLine splits "NAME;PARAM=VALUE:value" into its parts.
+func parseICSLine(line string) (icsProperty, error) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return icsProperty{}, fmt.Errorf("malformed line %q", line)
+	}
+	head := strings.Split(line[:colon], ";")
+	p := icsProperty{
+		name:   strings.ToUpper(head[0]),
+		params: make(map[string]string),
+		value:  unescapeICSText(line[colon+1:]),
+	}
+	for _, param := range head[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return p, nil
+}
+
+func unescapeICSText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
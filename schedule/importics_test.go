@@ -0,0 +1,75 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitSummary(t *testing.T) {
+	tests := []struct {
+		summary    string
+		home, away string
+		wantErr    bool
+	}{
+		{"Red vs Blue", "Red", "Blue", false},
+		{"Red VS. Blue", "Red", "Blue", false},
+		{"Red v Blue", "Red", "Blue", false},
+		{"Blue @ Red", "Red", "Blue", false},
+		{"Blue at Red", "Red", "Blue", false},
+		{"10U Red Sox vs 10U Blue Jays", "10U Red Sox", "10U Blue Jays", false},
+		{"Bats at Bay vs Cats", "Bats at Bay", "Cats", false},
+		{"Picture day", "", "", true},
+		{"vs Blue", "", "", true},
+		{"Red vs ", "", "", true},
+	}
+	for _, tt := range tests {
+		home, away, err := splitSummary(tt.summary)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitSummary(%q): error = %v, want error %v", tt.summary, err, tt.wantErr)
+			continue
+		}
+		if home != tt.home || away != tt.away {
+			t.Errorf("splitSummary(%q) = %q, %q, want %q, %q", tt.summary, home, away, tt.home, tt.away)
+		}
+	}
+}
+
+func TestParseICSDateTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	tests := []struct {
+		value   string
+		tzid    string
+		loc     *time.Location
+		want    string
+		wantErr bool
+	}{
+		{"20250111T150000Z", "", newYork, "2025-01-11 10:00", false},
+		{"20250601T150000Z", "", newYork, "2025-06-01 11:00", false},
+		{"20250111T030000Z", "", newYork, "2025-01-10 22:00", false},
+		{"20250111T150000Z", "", time.UTC, "2025-01-11 15:00", false},
+		{"20250111T150000Z", "", nil, "2025-01-11 15:00", false},
+		{"20250111T090000", "America/Chicago", newYork, "2025-01-11 10:00", false},
+		{"20250111T090000", "America/New_York", newYork, "2025-01-11 09:00", false},
+		{"20250111T090000", "Mars/Base", newYork, "2025-01-11 09:00", false},
+		{"20250111T090000", "", newYork, "2025-01-11 09:00", false},
+		{"2025-01-11", "", newYork, "", true},
+		{"20250111T150000z", "", newYork, "", true},
+	}
+	for _, tt := range tests {
+		p := icsProperty{name: "DTSTART", params: map[string]string{}, value: tt.value}
+		if tt.tzid != "" {
+			p.params["TZID"] = tt.tzid
+		}
+		got, err := parseICSDateTime(p, tt.loc)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseICSDateTime(%q, TZID %q): error = %v, want error %v", tt.value, tt.tzid, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Format("2006-01-02 15:04") != tt.want {
+			t.Errorf("parseICSDateTime(%q, TZID %q) = %s, want %s", tt.value, tt.tzid, got.Format("2006-01-02 15:04"), tt.want)
+		}
+	}
+}
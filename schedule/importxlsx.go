@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// readXLSX appends the games in an Excel workbook to s.Games. A workbook with
+// several sheets is read as one sheet per division, named after the sheet;
+// a single sheet takes label instead. Either way a Division column wins.
+// Date and Time cells holding Excel dates are read by value rather than as
+// formatted, so any date format set in Excel is accepted.
+func (s *Schedule) readXLSX(filename, label string) error {
+	f, err := excelize.OpenFile(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	props, err := f.GetWorkbookProps()
+	if err != nil {
+		return err
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	sheets := f.GetSheetList()
+	for _, sheet := range sheets {
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			return fmt.Errorf("%s: sheet %q: %w", filename, sheet, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		s.useCellValues(f, sheet, rows, date1904)
+		division := label
+		if len(sheets) > 1 {
+			division = sheet
+		}
+		source := fmt.Sprintf("%s [%s]", filename, sheet)
+		s.appendRecords(source, division, rows, nil)
+	}
+	return nil
+}
+
+// useCellValues replaces the formatted text of each numeric Date and Time
+// cell in rows, read from sheet of f, with its value written in a layout
+// the config accepts. Text cells, and numbers that are not a date or a time
+// of day, are left to the usual parsers so bad values are reported.
+func (s *Schedule) useCellValues(f *excelize.File, sheet string, rows [][]string, date1904 bool) {
+	cols, err := s.Config.mapColumns(rows[0])
+	if err != nil {
+		return
+	}
+	for _, column := range []string{"Date", "Time"} {
+		i := cols.index[column]
+		for r := 1; r < len(rows); r++ {
+			if i >= len(rows[r]) {
+				continue
+			}
+			cell, err := excelize.CoordinatesToCellName(i+1, r+1)
+			if err != nil {
+				continue
+			}
+			typ, err := f.GetCellType(sheet, cell)
+			if err != nil || (typ != excelize.CellTypeUnset && typ != excelize.CellTypeNumber && typ != excelize.CellTypeDate) {
+				continue
+			}
+			v, err := f.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				continue
+			}
+			if text, ok := s.Config.excelValueText(column, v, date1904); ok {
+				rows[r][i] = text
+			}
+		}
+	}
+}
+
+// excelValueText returns the numeric cell value v of the Date or Time column
+// as text in the first accepted layout that reads back as the same date or
+// clock time. A Date must be a serial of 1 or more; a Time must be a
+// fraction of a day, alone or as part of a date and time. Whole numbers
+// such as 18 are not times.
+func (c *Config) excelValueText(column, v string, date1904 bool) (string, bool) {
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil || serial < 0 {
+		return "", false
+	}
+	whole := serial == math.Trunc(serial)
+	if column == "Date" && serial < 1 {
+		return "", false
+	}
+	if column == "Time" && serial >= 1 && whole {
+		return "", false
+	}
+	t, err := excelize.ExcelDateToTime(serial, date1904)
+	if err != nil {
+		return "", false
+	}
+	t = t.Round(time.Minute)
+
+	layouts, parse := c.dateFormats(), c.ParseDate
+	same := func(p time.Time) bool { return p.Year() == t.Year() && p.YearDay() == t.YearDay() }
+	if column == "Time" {
+		layouts, parse = c.timeFormats(), c.ParseTime
+		same = func(p time.Time) bool { return p.Hour() == t.Hour() && p.Minute() == t.Minute() }
+	}
+	for _, layout := range layouts {
+		text := t.Format(layout)
+		if p, err := parse(text); err == nil && same(p) {
+			return text, true
+		}
+	}
+	return "", false
+}
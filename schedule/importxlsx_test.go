@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExcelValueText(t *testing.T) {
+	c := &Config{}
+	tests := []struct {
+		column, value string
+		want          string
+		ok            bool
+	}{
+		{"Time", "0.375", "09:00", true},
+		{"Time", "0.4375", "10:30", true},
+		{"Time", "0", "00:00", true},
+		{"Time", "45661.375", "09:00", true},
+		{"Time", "18", "", false},
+		{"Time", "45661", "", false},
+		{"Time", "-0.5", "", false},
+		{"Time", "9:00", "", false},
+		{"Date", "45661", "1/4/2025", true},
+		{"Date", "45661.375", "1/4/2025", true},
+		{"Date", "0.375", "", false},
+		{"Date", "1/4/2025", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := c.excelValueText(tt.column, tt.value, false)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("excelValueText(%s, %q) = %q, %v, want %q, %v", tt.column, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUseCellValues(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	cells := [][]interface{}{
+		{"Home", "Away", "Date", "Time", "Location"},
+		{"Red", "Blue", 45661, 18, "Field #1"},
+		{"Red", "Blue", 45661, "9", "Field #1"},
+		{"Red", "Blue", 45661, 0.375, "Field #1"},
+		{"Red", "Blue", "1/4/2025", 45661.375, "Field #1"},
+		{"Red", "Blue", 0.375, "9:00", "Field #1"},
+	}
+	for r, row := range cells {
+		cell, _ := excelize.CoordinatesToCellName(1, r+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Schedule{Config: &Config{}}
+	s.useCellValues(f, "Sheet1", rows, false)
+	want := [][2]string{
+		{"1/4/2025", "18"},
+		{"1/4/2025", "9"},
+		{"1/4/2025", "09:00"},
+		{"1/4/2025", "09:00"},
+		{"0.375", "9:00"},
+	}
+	for i, w := range want {
+		if got := [2]string{rows[i+1][2], rows[i+1][3]}; got != w {
+			t.Errorf("row %d: Date, Time = %q, want %q", i+2, got, w)
+		}
+	}
+}
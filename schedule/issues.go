@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity says whether an Issue stops the schedule from being published.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Issue is a problem found in an input file. Line is 0 when the problem is
+// with the file as a whole and Column is empty when it is with a whole row.
+type Issue struct {
+	File     string
+	Line     int
+	Column   string
+	Severity Severity
+	Message  string
+}
+
+func (i Issue) String() string {
+	var b strings.Builder
+	b.WriteString(i.File)
+	if i.Line > 0 {
+		fmt.Fprintf(&b, ":%d", i.Line)
+	}
+	if i.Column != "" {
+		fmt.Fprintf(&b, " [%s]", i.Column)
+	}
+	fmt.Fprintf(&b, " %s: %s", i.Severity, i.Message)
+	return b.String()
+}
+
+func (s *Schedule) report(file string, line int, column string, sev Severity, format string, args ...interface{}) {
+	s.Issues = append(s.Issues, Issue{file, line, column, sev, fmt.Sprintf(format, args...)})
+}
+
+// CountIssues returns the number of errors and warnings in s.Issues.
+func (s *Schedule) CountIssues() (errs, warnings int) {
+	for _, i := range s.Issues {
+		if i.Severity == SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	return errs, warnings
+}
+
+// SortIssues orders s.Issues by file and line.
+func (s *Schedule) SortIssues() {
+	sort.SliceStable(s.Issues, func(a, b int) bool {
+		if s.Issues[a].File != s.Issues[b].File {
+			return s.Issues[a].File < s.Issues[b].File
+		}
+		return s.Issues[a].Line < s.Issues[b].Line
+	})
+}
+
+// Plural returns one when n is 1 and many otherwise.
+func Plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// checkGame reports the problems with a game that do not stop it from being
+// read. Date and time are checked as they are parsed.
+func (s *Schedule) checkGame(g Game) {
+	if g.Home == "" {
+		s.report(g.Source, g.Line, "Home", SeverityError, "team name is blank")
+	}
+	if g.Away == "" {
+		s.report(g.Source, g.Line, "Away", SeverityError, "team name is blank")
+	}
+	_, f, err := s.Config.Locate(g.Venue, g.Location)
+	if err != nil {
+		s.report(g.Source, g.Line, "Location", SeverityError, "%v", err)
+		return
+	}
+	if !s.Config.Allows(g.Division, f) {
+		s.report(g.Source, g.Line, "Location", SeverityError, "%s plays on %s fields, %s is %s",
+			g.Division, strings.Join(s.Config.sizesFor(g.Division), " or "), f.Name, sizeName(f.Size))
+	}
+}
+
+// sizeName returns a field size for messages.
+func sizeName(size string) string {
+	if size == "" {
+		return "not sized"
+	}
+	return size
+}
+
+// checkDate reports a game outside the season window.
+func (s *Schedule) checkDate(g Game) {
+	day := g.Day()
+	if day.Before(s.Config.startDate) || day.After(s.Config.endDate) {
+		s.report(g.Source, g.Line, "Date", SeverityError, "%s is outside the season (%s to %s)", g.Date(), s.Config.Season.Start, s.Config.Season.End)
+	}
+}
@@ -0,0 +1,246 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// OpenSlotsFileName is the file name, without extension, of the open slot
+// lists.
+const OpenSlotsFileName string = "open_slots"
+
+// SlotFilter selects the open fields listed by the slot renderers. Zero
+// fields match everything.
+type SlotFilter struct {
+	From, To time.Time
+	Venue    string
+	Size     string
+	Division string
+}
+
+// matches reports whether the open field g passes f.
+func (c *Config) matches(g Game, f SlotFilter) bool {
+	day := g.Day()
+	if (!f.From.IsZero() && day.Before(f.From)) || (!f.To.IsZero() && day.After(f.To)) {
+		return false
+	}
+	if f.Venue != "" && !strings.EqualFold(g.Venue, f.Venue) {
+		return false
+	}
+	_, field, err := c.Locate(g.Venue, g.Location)
+	if err != nil {
+		return false
+	}
+	if f.Size != "" && !strings.EqualFold(field.Size, f.Size) {
+		return false
+	}
+	return f.Division == "" || c.Allows(f.Division, field)
+}
+
+// OpenDays returns days with only the open fields that pass filter, sorted
+// by time, venue and field so each day reads in time order. Days left with
+// none are dropped.
+func (s *Schedule) OpenDays(days []Day, filter SlotFilter) []Day {
+	var open []Day
+	for _, d := range days {
+		var games []Game
+		for _, g := range d.Games {
+			if g.IsOpen() && s.Config.matches(g, filter) {
+				games = append(games, g)
+			}
+		}
+		if len(games) > 0 {
+			SortGames(games)
+			open = append(open, Day{Date: d.Date, Games: games})
+		}
+	}
+	return open
+}
+
+// slotColumns are the columns of the CSV and Excel slot lists.
+var slotColumns = []string{"Date", "Day", "Time", "Venue", "Field", "Size", "Lights", "Divisions"}
+
+// slotRecord returns the open field g as a row of slotColumns.
+func (s *Schedule) slotRecord(g Game) []string {
+	_, field, _ := s.Config.Locate(g.Venue, g.Location)
+	lights := ""
+	if field.Lights {
+		lights = "Yes"
+	}
+	return []string{
+		g.Date(),
+		g.Start.Weekday().String(),
+		g.Start.Format(outputTimeFormat),
+		g.Venue,
+		g.Location,
+		field.Size,
+		lights,
+		strings.Join(g.Divisions, ", "),
+	}
+}
+
+// RenderSlotsCSV writes every open field in days as CSV to w, one row per
+// field and time slot.
+func (s *Schedule) RenderSlotsCSV(w io.Writer, days []Day) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(slotColumns); err != nil {
+		return err
+	}
+	for _, d := range days {
+		for _, g := range d.Games {
+			if err := writer.Write(s.slotRecord(g)); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// openSlotJSON is one open field in the JSON slot list.
+type openSlotJSON struct {
+	Start     string   `json:"start"`
+	Date      string   `json:"date"`
+	Time      string   `json:"time"`
+	Venue     string   `json:"venue"`
+	Field     string   `json:"field"`
+	Size      string   `json:"size,omitempty"`
+	Lights    bool     `json:"lights"`
+	Divisions []string `json:"divisions,omitempty"`
+}
+
+// RenderSlotsJSON writes every open field in days to w as a JSON array.
+// Divisions is left out when every division may book the field.
+func (s *Schedule) RenderSlotsJSON(w io.Writer, days []Day) error {
+	slots := []openSlotJSON{}
+	for _, d := range days {
+		for _, g := range d.Games {
+			_, field, _ := s.Config.Locate(g.Venue, g.Location)
+			slots = append(slots, openSlotJSON{
+				Start:     g.Start.Format("2006-01-02T15:04"),
+				Date:      g.Date(),
+				Time:      g.Start.Format(outputTimeFormat),
+				Venue:     g.Venue,
+				Field:     g.Location,
+				Size:      field.Size,
+				Lights:    field.Lights,
+				Divisions: g.Divisions,
+			})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(slots)
+}
+
+// slotSheet is the name of the sheet RenderSlotsExcel writes.
+const slotSheet = "Open Slots"
+
+// RenderSlotsExcel writes every open field in days to w as a workbook with
+// a single filterable sheet. Style problems are returned as Warnings.
+func (s *Schedule) RenderSlotsExcel(w io.Writer, days []Day) (err error) {
+	assets, err := s.workbookAssets()
+	if err != nil {
+		return err
+	}
+	f := excelize.NewFile()
+
+	var warnings Warnings
+	warn := func(err error) {
+		if err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+	defer func() {
+		warn(f.Close())
+		if err == nil && len(warnings) > 0 {
+			err = warnings
+		}
+	}()
+
+	if err := f.SetSheetName("Sheet1", slotSheet); err != nil {
+		return err
+	}
+	warn(f.SetDefaultFont("Arial Rounded MT Bold"))
+
+	rows := [][]string{slotColumns}
+	for _, d := range days {
+		for _, g := range d.Games {
+			rows = append(rows, s.slotRecord(g))
+		}
+	}
+	for r, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, r+1)
+		if err := f.SetSheetRow(slotSheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	lastCol, _ := excelize.ColumnNumberToName(len(slotColumns))
+	lastCell := fmt.Sprintf("%s%d", lastCol, len(rows))
+	warn(f.SetColWidth(slotSheet, "A", "C", 14))
+	warn(f.SetColWidth(slotSheet, "D", "D", 30))
+	warn(f.SetColWidth(slotSheet, "E", lastCol, 14))
+	warn(f.SetColWidth(slotSheet, lastCol, lastCol, 24))
+
+	titleStyle, err := f.NewStyle(assets.rowTitle)
+	warn(err)
+	warn(f.SetCellStyle(slotSheet, "A1", lastCol+"1", titleStyle))
+	if len(rows) > 1 {
+		border, err := f.NewStyle(&excelize.Style{
+			Border: assets.border.Border,
+			Font:   &excelize.Font{Size: 12, Color: "000000"},
+		})
+		warn(err)
+		warn(f.SetCellStyle(slotSheet, "A2", lastCell, border))
+	}
+	warn(f.SetPanes(slotSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}))
+	warn(f.AutoFilter(slotSheet, "A1:"+lastCell, nil))
+	return f.Write(w)
+}
+
+// slotsRenderer writes the season's open fields in one format. It is not
+// registered; the slots command builds it with SlotsRenderer.
+type slotsRenderer struct {
+	s      *Schedule
+	format string
+}
+
+// SlotFormats are the formats SlotsRenderer accepts.
+var SlotFormats = []string{"csv", "json", "excel"}
+
+// SlotsRenderer returns the renderer that lists open fields in format,
+// one of SlotFormats. Give it the days returned by OpenDays.
+func (s *Schedule) SlotsRenderer(format string) (Renderer, error) {
+	for _, f := range SlotFormats {
+		if f == format {
+			return slotsRenderer{s, format}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown slot format %q (available: %s)", format, strings.Join(SlotFormats, ", "))
+}
+
+func (slotsRenderer) Name() string { return "slots" }
+func (slotsRenderer) Scope() Scope { return PerSeason }
+func (r slotsRenderer) Ext() string {
+	if r.format == "excel" {
+		return ".xlsx"
+	}
+	return "." + r.format
+}
+func (r slotsRenderer) Render(w io.Writer, days []Day) error {
+	switch r.format {
+	case "json":
+		return r.s.RenderSlotsJSON(w, days)
+	case "excel":
+		return r.s.RenderSlotsExcel(w, days)
+	}
+	return r.s.RenderSlotsCSV(w, days)
+}
+func (slotsRenderer) artifactName() string { return OpenSlotsFileName }
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMatches(t *testing.T) {
+	c := testConfig(t, nil)
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	open := func(d int, venue, location string) Game {
+		v, f, err := c.Locate(venue, location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return newOpenField(day(d).Add(9*time.Hour), v, f)
+	}
+	north, south, main := open(11, "Ballpark", "North"), open(11, "Ballpark", "South"), open(11, "Lakeside", "Main")
+	unknown := north
+	unknown.Location = "Field #9"
+	tests := []struct {
+		name   string
+		game   Game
+		filter SlotFilter
+		want   bool
+	}{
+		{"no filter", south, SlotFilter{}, true},
+		{"on the first day", north, SlotFilter{From: day(11), To: day(11)}, true},
+		{"before the range", north, SlotFilter{From: day(12)}, false},
+		{"after the range", north, SlotFilter{To: day(10)}, false},
+		{"venue in any case", main, SlotFilter{Venue: "lakeside"}, true},
+		{"other venue", north, SlotFilter{Venue: "Lakeside"}, false},
+		{"size in any case", north, SlotFilter{Size: "FULL"}, true},
+		{"other size", south, SlotFilter{Size: "full"}, false},
+		{"division allowed", main, SlotFilter{Division: "15U"}, true},
+		{"division not allowed", south, SlotFilter{Division: "15U"}, false},
+		{"division without a rule", south, SlotFilter{Division: "7U"}, true},
+		{"every filter", main, SlotFilter{From: day(4), To: day(18), Venue: "Lakeside", Size: "full", Division: "15U"}, true},
+		{"unknown field", unknown, SlotFilter{}, false},
+	}
+	for _, tt := range tests {
+		if got := c.matches(tt.game, tt.filter); got != tt.want {
+			t.Errorf("%s: matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOpenDays(t *testing.T) {
+	c := testConfig(t, noSizes)
+	games := []Game{
+		testGame(t, c, 4, "9:00", "", "North", "10U", "Red", "Blue"),
+		testGame(t, c, 4, "9:00", "", "South", "10U", "Gold", "Green"),
+		testGame(t, c, 4, "9:00", "Lakeside", "Main", "10U", "Sox", "Cubs"),
+	}
+	s := &Schedule{Config: c, Games: games}
+	days := s.Days()
+
+	var got []string
+	for _, d := range s.OpenDays(days[:2], SlotFilter{}) {
+		for _, g := range d.Games {
+			got = append(got, g.Date()+" "+describe(g))
+		}
+	}
+	want := []string{
+		"1/4/2025 10:30 Ballpark North: open",
+		"1/4/2025 10:30 Ballpark South: open",
+		"1/4/2025 10:30 Lakeside Main: open",
+		"1/11/2025 09:00 Ballpark North: open",
+		"1/11/2025 09:00 Ballpark South: open",
+		"1/11/2025 09:00 Lakeside Main: open",
+		"1/11/2025 10:30 Ballpark North: open",
+		"1/11/2025 10:30 Ballpark South: open",
+		"1/11/2025 10:30 Lakeside Main: open",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenDays:\ngot  %q\nwant %q", got, want)
+	}
+
+	if open := s.OpenDays(days[:2], SlotFilter{Venue: "Lakeside", To: days[0].Date}); len(open) != 1 || len(open[0].Games) != 1 {
+		t.Errorf("OpenDays filtered to Lakeside on 1/4/2025 = %v, want one day with one slot", open)
+	}
+	if open := s.OpenDays(days[:1], SlotFilter{Size: "short", Venue: "Lakeside"}); len(open) != 0 {
+		t.Errorf("OpenDays with no matching slot = %v, want no days", open)
+	}
+}
+
+func TestHasSize(t *testing.T) {
+	c := testConfig(t, nil)
+	if got, want := c.Sizes(), []string{"full", "short"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sizes() = %q, want %q", got, want)
+	}
+	for size, want := range map[string]bool{"full": true, " Short ": true, "ful": false, "": false} {
+		if got := c.HasSize(size); got != want {
+			t.Errorf("HasSize(%q) = %v, want %v", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package schedule
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scope says what a Renderer's artifact covers.
+type Scope int
+
+const (
+	// PerDay renderers write one artifact for each game day.
+	PerDay Scope = iota
+	// PerSeason renderers write one artifact holding every game day.
+	PerSeason
+)
+
+// Renderer writes the schedule in one output format.
+type Renderer interface {
+	// Name is the name the renderer is enabled by in the config.
+	Name() string
+	// Ext is the file extension of the artifacts, including the dot.
+	Ext() string
+	// Scope says whether Render is given one day or the whole season.
+	Scope() Scope
+	// Render writes the artifact for days to w. PerDay renderers are always
+	// given exactly one day. A renderer that wrote a usable artifact but
+	// could not apply all of its formatting returns Warnings.
+	Render(w io.Writer, days []Day) error
+}
+
+// Warnings are problems that did not stop an artifact from being written,
+// such as a cell style that could not be applied.
+type Warnings []error
+
+func (w Warnings) Error() string {
+	msgs := make([]string, len(w))
+	for i, err := range w {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// RendererFactory builds a renderer for a loaded schedule.
+type RendererFactory func(s *Schedule) Renderer
+
+var renderers = make(map[string]RendererFactory)
+
+// RegisterRenderer makes a renderer available under name, so it can be
+// enabled in the config. It panics if name is already taken.
+func RegisterRenderer(name string, factory RendererFactory) {
+	if _, dup := renderers[name]; dup {
+		panic("schedule: renderer " + name + " registered twice")
+	}
+	renderers[name] = factory
+}
+
+// RendererNames returns the names of every registered renderer, sorted.
+func RendererNames() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRenderer returns the renderer registered under name.
+func (s *Schedule) NewRenderer(name string) (Renderer, error) {
+	factory, ok := renderers[name]
+	if !ok {
+		return nil, unknownRenderer(name)
+	}
+	return factory(s), nil
+}
+
+// unsupportedRenderers are outputs that are asked for but not written,
+// with what to do instead. There is no PDF renderer: the workbooks are
+// laid out to print, so PDFs are made by printing them or the HTML page.
+var unsupportedRenderers = map[string]string{
+	"pdf": "print the excel or html output to PDF",
+}
+
+// unknownRenderer returns the error for a renderer name that is not
+// registered.
+func unknownRenderer(name string) error {
+	if hint, ok := unsupportedRenderers[name]; ok {
+		return fmt.Errorf("renderer %q is not supported; %s (available: %s)", name, hint, strings.Join(RendererNames(), ", "))
+	}
+	return fmt.Errorf("unknown renderer %q (available: %s)", name, strings.Join(RendererNames(), ", "))
+}
+
+// Renderers returns the renderers enabled in the config, in config order.
+func (s *Schedule) Renderers() ([]Renderer, error) {
+	var out []Renderer
+	for _, name := range s.Config.renderers() {
+		r, err := s.NewRenderer(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// SeasonFileName is the file name, without extension, of PerSeason artifacts.
+const SeasonFileName string = "season_schedule"
+
+// TempFileSuffix marks the temporary files WriteFile renders into.
+const TempFileSuffix string = ".tmp-"
+
+// IsArtifactFile reports whether name, a file name with extension, is one
+// a Renderer writes or a temporary file left by an interrupted WriteFile.
+func IsArtifactFile(name string) bool {
+	if strings.HasPrefix(name, ".") && strings.Contains(name, TempFileSuffix) {
+		return true
+	}
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.HasPrefix(base, dateFilePrefix) || base == SeasonFileName || base == OpenSlotsFileName
+}
+
+// artifactNamer is implemented by renderers whose artifact has a name of its
+// own.
+type artifactNamer interface {
+	artifactName() string
+}
+
+// ArtifactName returns the file name, without extension, of the artifact r
+// writes for days.
+func ArtifactName(r Renderer, days []Day) string {
+	if n, ok := r.(artifactNamer); ok {
+		return n.artifactName()
+	}
+	if r.Scope() == PerSeason || len(days) != 1 {
+		return SeasonFileName
+	}
+	return DateFileName(days[0].Date)
+}
+
+func init() {
+	RegisterRenderer("excel", func(s *Schedule) Renderer { return excelRenderer{s} })
+	RegisterRenderer("csv", func(s *Schedule) Renderer { return csvRenderer{s} })
+	RegisterRenderer("html", func(s *Schedule) Renderer { return htmlRenderer{s} })
+	RegisterRenderer("ics", func(s *Schedule) Renderer { return icsRenderer{s} })
+}
+
+// excelRenderer writes the printable workbook of each day.
+type excelRenderer struct{ s *Schedule }
+
+func (excelRenderer) Name() string { return "excel" }
+func (excelRenderer) Ext() string  { return ".xlsx" }
+func (excelRenderer) Scope() Scope { return PerDay }
+func (r excelRenderer) Render(w io.Writer, days []Day) error {
+	return r.s.RenderExcel(w, days[0].Games)
+}
+
+// csvRenderer writes the CSV of each day.
+type csvRenderer struct{ s *Schedule }
+
+func (csvRenderer) Name() string { return "csv" }
+func (csvRenderer) Ext() string  { return ".csv" }
+func (csvRenderer) Scope() Scope { return PerDay }
+func (r csvRenderer) Render(w io.Writer, days []Day) error {
+	return r.s.RenderCSV(w, days[0].Games)
+}
+
+// Fingerprint returns a hash of everything r's artifact for days is built
+// from: the config, the extra columns and the games. When it is unchanged
+// the artifact does not need to be rendered again.
+func (s *Schedule) Fingerprint(r Renderer, days []Day) string {
+	h := sha256.New()
+	cfg, _ := json.Marshal(s.Config)
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%q\x00", r.Name(), ArtifactName(r, days), cfg, s.Columns)
+	for _, d := range days {
+		for _, g := range d.Games {
+			fmt.Fprintf(h, "%s\x00%q\x00%q\x00%q\x00%q\x00%q\x00%d\x00%q\x00",
+				g.Start.Format(time.RFC3339), g.Division, g.Home, g.Away, g.Venue, g.Location, g.Field, g.Divisions)
+			for _, name := range s.Columns {
+				fmt.Fprintf(h, "%q\x00", g.Attrs[name])
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,501 @@
+// Package schedule loads a season of youth football games from the division
+// schedules, fills the unbooked fields of each time slot and renders the
+// result with the Renderers enabled in the config: printable Excel
+// workbooks, per-date CSVs, web pages or a season calendar.
+//
+// A typical caller loads the season config, reads the inputs and writes
+// one workbook per game day:
+//
+//	cfg, err := schedule.LoadConfig("season.json")
+//	...
+//	s := schedule.New(cfg)
+//	if err := s.Load(cfg.Inputs.Dir); err != nil {
+//		// s.Issues says what is wrong with which row.
+//	}
+//	for _, d := range s.Days() {
+//		err := s.WriteExcel(schedule.DateFileName(d.Date)+".xlsx", d.Games)
+//		...
+//	}
+package schedule
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule is a season's games and what was found while reading them.
+type Schedule struct {
+	Config *Config
+
+	// Games holds every game read so far. Load sorts it by date, time and
+	// field.
+	Games []Game
+
+	// Columns are the extra input columns, such as Notes, in the order they
+	// were first seen. They are carried through to every output.
+	Columns []string
+
+	// Closures are the field closures read from the closures file.
+	Closures []Closure
+
+	// Issues are the problems found in the input files.
+	Issues []Issue
+
+	// Logger receives debug messages about the work done. When it is nil
+	// slog.Default() is used.
+	Logger *slog.Logger
+
+	// assets are the logos and styles shared by every workbook.
+	assetsOnce sync.Once
+	assets     *excelAssets
+	assetsErr  error
+}
+
+// New returns an empty schedule for the season described by cfg.
+func New(cfg *Config) *Schedule {
+	return &Schedule{Config: cfg}
+}
+
+// Day is one date's games, with the open fields filled in by Days.
+type Day struct {
+	Date  time.Time
+	Games []Game
+}
+
+func (s *Schedule) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+// divisionInput is one file to read and the division label for its games.
+type divisionInput struct {
+	path  string
+	label string
+}
+
+// Load reads the closures file, if the config names one, and every division
+// input file in dir, and sorts the games. Any problems are collected in
+// s.Issues; Load fails if any of them is an error.
+func (s *Schedule) Load(dir string) error {
+	inputs, err := s.discoverInputs(dir)
+	if err != nil {
+		return err
+	}
+	if path := s.Config.Closures; path != "" {
+		if err := s.readClosures(path); err != nil {
+			s.report(path, 0, "", SeverityError, "%v", err)
+		}
+		s.logger().Debug("read closures", "file", path, "closures", len(s.Closures))
+	}
+	for _, in := range inputs {
+		before := len(s.Games)
+		if err := s.ReadFile(in.path, in.label); err != nil {
+			s.report(in.path, 0, "", SeverityError, "%v", err)
+			continue
+		}
+		s.logger().Debug("read input", "file", in.path, "division", in.label, "games", len(s.Games)-before)
+	}
+	if errs, _ := s.CountIssues(); errs > 0 {
+		return fmt.Errorf("%d %s in the input files", errs, Plural(errs, "error", "errors"))
+	}
+	SortGames(s.Games)
+	return nil
+}
+
+// Inputs returns the paths of the files Load reads from dir.
+func (s *Schedule) Inputs(dir string) ([]string, error) {
+	inputs, err := s.discoverInputs(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(inputs))
+	for i, in := range inputs {
+		paths[i] = in.path
+	}
+	return paths, nil
+}
+
+// discoverInputs lists the files named in the inputs manifest, or every
+// .csv, .xlsx and .ics file in dir when the manifest is empty.
+func (s *Schedule) discoverInputs(dir string) ([]divisionInput, error) {
+	var inputs []divisionInput
+	if len(s.Config.Inputs.Divisions) > 0 {
+		for _, d := range s.Config.Inputs.Divisions {
+			path := filepath.Join(dir, d.File)
+			label := d.Label
+			if label == "" {
+				label = divisionFromFileName(path)
+			}
+			inputs = append(inputs, divisionInput{path, label})
+		}
+		return inputs, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input directory: %w", err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !isInputFile(entry.Name()) || s.isClosuresFile(path) {
+			continue
+		}
+		inputs = append(inputs, divisionInput{path, divisionFromFileName(path)})
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no division files found in %s", dir)
+	}
+	return inputs, nil
+}
+
+// isClosuresFile reports whether path is the closures file, which is not a
+// division schedule even when it is kept with them.
+func (s *Schedule) isClosuresFile(path string) bool {
+	return s.Config.Closures != "" && filepath.Clean(path) == filepath.Clean(s.Config.Closures)
+}
+
+// isInputFile reports whether name is a file type ReadFile understands.
+// Excel's "~$" lock files are skipped.
+func isInputFile(name string) bool {
+	if strings.HasPrefix(name, "~$") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".xlsx", ".ics":
+		return true
+	}
+	return false
+}
+
+// divisionFromFileName returns the file name without its directory or extension.
+func divisionFromFileName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// ReadFile appends the games in one .csv, .xlsx or .ics division file to
+// s.Games. Team names are prefixed with label unless the file has a
+// Division column. Problems with single rows are added to s.Issues; the
+// error is for a file that cannot be read at all.
+func (s *Schedule) ReadFile(path, label string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlsx":
+		return s.readXLSX(path, label)
+	case ".ics":
+		return s.readICS(path, label)
+	case ".csv":
+		return s.readCSV(path, label)
+	}
+	return fmt.Errorf("%s: unsupported input file type", path)
+}
+
+// readCSV appends the games in filename to s.Games. Columns are matched by
+// header name.
+func (s *Schedule) readCSV(filename, label string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	s.appendRecords(filename, label, records, lines)
+	return nil
+}
+
+// appendRecords appends the games in records, a header row followed by one
+// row per game, to s.Games. source and lines, the line each record starts
+// on, locate rows in issues; lines may be nil when records[i] is on line
+// i+1. Rows with a date or time that cannot be parsed are reported and left
+// out.
+func (s *Schedule) appendRecords(source, label string, records [][]string, lines []int) {
+	if len(records) == 0 {
+		return
+	}
+	lineOf := func(i int) int {
+		if lines != nil {
+			return lines[i]
+		}
+		return i + 1
+	}
+
+	cols, err := s.Config.mapColumns(records[0])
+	if err != nil {
+		s.report(source, lineOf(0), "", SeverityError, "header: %v", err)
+		return
+	}
+	for _, name := range cols.extraNames {
+		s.addColumn(name)
+	}
+
+	// Skip header row
+	for i := 1; i < len(records); i++ {
+		record := records[i]
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(record) < len(records[0]) {
+			s.report(source, lineOf(i), "", SeverityWarning, "row has %d cells, the header has %d", len(record), len(records[0]))
+		}
+
+		division := label
+		if d := cols.get(record, divisionColumn); d != "" {
+			division = d
+		}
+		g := Game{
+			Division: division,
+			Home:     cols.get(record, "Home"),
+			Away:     cols.get(record, "Away"),
+			Attrs:    make(map[string]string),
+			Source:   source,
+			Line:     lineOf(i),
+		}
+		s.Config.place(&g, cols.get(record, venueColumn), cols.get(record, "Location"))
+		for _, name := range cols.extraNames {
+			g.Attrs[name] = cols.get(record, name)
+		}
+		s.checkGame(g)
+
+		date, dateErr := s.Config.ParseDate(cols.get(record, "Date"))
+		if dateErr != nil {
+			s.report(source, lineOf(i), "Date", SeverityError, "%q: %v", cols.get(record, "Date"), dateErr)
+		}
+		start, timeErr := s.Config.ParseTime(cols.get(record, "Time"))
+		if timeErr != nil {
+			s.report(source, lineOf(i), "Time", SeverityError, "%q: %v", cols.get(record, "Time"), timeErr)
+		}
+		if dateErr != nil || timeErr != nil {
+			continue
+		}
+		g.Start = combineDateTime(date, start)
+		s.checkDate(g)
+		s.checkClosure(g)
+		s.Games = append(s.Games, g)
+	}
+}
+
+// isBlankRecord reports whether every cell in record is empty.
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Days splits s.Games into one Day per date, in date order, and fills in
+// the open fields of each. Dates with a slot template are included even when
+// no games are booked on them.
+func (s *Schedule) Days() []Day {
+	var days []Day
+	byDate := make(map[time.Time]int)
+	for _, g := range s.Games {
+		i, ok := byDate[g.Day()]
+		if !ok {
+			i = len(days)
+			byDate[g.Day()] = i
+			days = append(days, Day{Date: g.Day()})
+		}
+		days[i].Games = append(days[i].Games, g)
+	}
+	for _, date := range s.Config.slotDates() {
+		if _, ok := byDate[date]; !ok {
+			days = append(days, Day{Date: date})
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+
+	for i := range days {
+		days[i].Games = s.fillDay(days[i].Date, days[i].Games)
+	}
+	return days
+}
+
+// Day returns the day of Days on the given date.
+func (s *Schedule) Day(date string) (Day, error) {
+	d, err := s.Config.ParseDate(date)
+	if err != nil {
+		return Day{}, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	for _, candidate := range s.Days() {
+		if candidate.Date.Equal(d) {
+			return candidate, nil
+		}
+	}
+	return Day{}, fmt.Errorf("no games on %s", d.Format(DateFormat))
+}
+
+// FillMissingFields adds an open field filler for every venue field that has
+// no game in a time slot. Each venue is filled on its own, in the order of
+// the config, from the slots with games at it plus those in the day's slot
+// template. Each slot lists the fields in the order of the venue's field
+// list, followed by any games on fields not in it. Games at venues not in
+// the config come last.
+func (s *Schedule) FillMissingFields(games []Game) []Game {
+	if len(games) == 0 {
+		return games
+	}
+	return s.fillDay(games[0].Day(), games)
+}
+
+// fillDay is FillMissingFields for the games on day, which may be none. A
+// weekday template only fills the venues with games that day; a date
+// template, or a day without games, fills every venue.
+func (s *Schedule) fillDay(day time.Time, games []Game) []Game {
+	sorted := append([]Game(nil), games...)
+	SortGames(sorted)
+
+	byVenue := make(map[string][]Game)
+	for _, g := range sorted {
+		byVenue[g.Venue] = append(byVenue[g.Venue], g)
+	}
+	_, everyVenue := s.Config.slots.dates[day]
+	everyVenue = everyVenue || len(games) == 0
+
+	var result []Game
+	for _, v := range s.Config.Venues {
+		venueGames, ok := byVenue[v.Name]
+		delete(byVenue, v.Name)
+		if ok || everyVenue {
+			result = append(result, s.fillVenue(day, v, venueGames)...)
+		}
+	}
+	for _, g := range sorted {
+		if _, unknown := byVenue[g.Venue]; unknown {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// fillVenue fills the slots on day at one venue. games are the venue's
+// games, sorted. Each open field lists the divisions that may book it;
+// closed fields get a closed filler instead.
+func (s *Schedule) fillVenue(day time.Time, venue VenueConfig, games []Game) []Game {
+	eligible := make(map[int][]string)
+	for _, f := range venue.FieldList() {
+		eligible[f.Number] = s.eligibleDivisions(f)
+	}
+
+	var result []Game
+	next := 0
+	for _, t := range slotStarts(day, games, s.Config.slotTimes(day)) {
+		end := next
+		for end < len(games) && games[end].Start.Equal(t) {
+			end++
+		}
+		slot := games[next:end]
+		next = end
+
+		// Identify fields present
+		fieldToGames := make(map[int][]Game)
+		for _, g := range slot {
+			fieldToGames[g.Field] = append(fieldToGames[g.Field], g)
+		}
+
+		// Insert fillers for missing fields
+		for _, f := range venue.FieldList() {
+			if booked, ok := fieldToGames[f.Number]; ok {
+				result = append(result, booked...)
+				delete(fieldToGames, f.Number)
+			} else if c, closed := s.closure(venue.Name, f.Number, day); closed {
+				result = append(result, newClosedField(t, venue, f, c.Reason))
+			} else {
+				open := newOpenField(t, venue, f)
+				open.Divisions = eligible[f.Number]
+				result = append(result, open)
+			}
+		}
+		for _, g := range slot {
+			if _, unknown := fieldToGames[g.Field]; unknown {
+				result = append(result, g)
+			}
+		}
+	}
+	return result
+}
+
+// slotStarts returns every start time on day, from the games, which must be
+// sorted, and the day's slot template, in order and without repeats.
+func slotStarts(day time.Time, games []Game, template []time.Time) []time.Time {
+	var starts []time.Time
+	for _, g := range games {
+		starts = append(starts, g.Start)
+	}
+	for _, clock := range template {
+		starts = append(starts, combineDateTime(day, clock))
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	var unique []time.Time
+	for _, t := range starts {
+		if len(unique) == 0 || !unique[len(unique)-1].Equal(t) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// SortGames sorts games by date, time, venue and field. Games that tie on
+// all four are kept in a fixed order by their location, teams and input
+// row, so the same inputs always give the same order.
+func SortGames(games []Game) {
+	sort.SliceStable(games, func(i, j int) bool {
+		a, b := games[i], games[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if a.Venue != b.Venue {
+			return a.Venue < b.Venue
+		}
+		if a.Field != b.Field && a.Field != 0 && b.Field != 0 {
+			return a.Field < b.Field
+		}
+		for _, pair := range [][2]string{
+			{a.Location, b.Location},
+			{a.Division, b.Division},
+			{a.Home, b.Home},
+			{a.Away, b.Away},
+			{a.Source, b.Source},
+		} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return a.Line < b.Line
+	})
+}
+
+// dateFilePrefix starts the name of every per-date output file.
+const dateFilePrefix = "sorted_schedule_"
+
+// DateFileName returns the output file name, without extension, for a date.
+func DateFileName(date time.Time) string {
+	return dateFilePrefix + strings.ReplaceAll(date.Format(DateFormat), "/", "-")
+}
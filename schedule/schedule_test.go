@@ -0,0 +1,189 @@
+package schedule
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testConfig returns a validated config with two venues, Ballpark with a
+// full-size North and a short South field and Lakeside with one field, and
+// Saturday slots at 9:00 and 10:30, except on 1/18/2025, which has only
+// 9:00, and 1/25/2025, which has none. 15U plays on full-size fields only.
+// edit, if not nil, changes it first.
+func testConfig(t *testing.T, edit func(*Config)) *Config {
+	t.Helper()
+	c := &Config{
+		Version: configVersion,
+		Season:  SeasonConfig{Start: "1/3/2025", End: "3/24/2025"},
+		Style:   StyleConfig{Background: "#002060"},
+		Page:    PageConfig{Size: 1, Orientation: "landscape"},
+		Inputs:  InputsConfig{Dir: "data"},
+		Venues: []VenueConfig{
+			{Name: "Ballpark", Fields: []FieldConfig{
+				{Number: 1, Name: "North", Size: "full"},
+				{Number: 2, Name: "South", Size: "short"},
+			}},
+			{Name: "Lakeside", Address: "1 Lake Rd", Fields: []FieldConfig{{Number: 1, Name: "Main", Size: "full"}}},
+		},
+		FieldSizes: map[string][]string{"15U": {"full"}, "10U": {"full", "short"}},
+		Slots: SlotsConfig{
+			Weekdays: map[string][]string{"Saturday": {"9:00", "10:30"}},
+			Dates:    map[string][]string{"1/18/2025": {"9:00"}, "1/25/2025": {}},
+		},
+	}
+	if edit != nil {
+		edit(c)
+	}
+	if err := c.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	return c
+}
+
+// testGame returns a game at venue and field on a January 2025 day.
+func testGame(t *testing.T, c *Config, day int, clock, venue, location, division, home, away string) Game {
+	t.Helper()
+	start, err := c.ParseTime(clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := Game{
+		Start:    combineDateTime(time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), start),
+		Division: division,
+		Home:     home,
+		Away:     away,
+	}
+	c.place(&g, venue, location)
+	return g
+}
+
+// describe returns g as "15:04 Venue Location: teams", with open fields
+// listing the divisions that may book them.
+func describe(g Game) string {
+	teams := g.HomeLabel() + " vs " + g.AwayLabel()
+	switch {
+	case g.IsOpen():
+		teams = "open"
+		if g.Divisions != nil {
+			teams += " " + strings.Join(g.Divisions, ",")
+		}
+	case g.Closed:
+		teams = "closed " + g.Home
+	}
+	return fmt.Sprintf("%s %s %s: %s", g.Start.Format("15:04"), g.Venue, g.Location, teams)
+}
+
+// noSizes drops the field size rules, so every open field is open to
+// every division.
+func noSizes(c *Config) { c.FieldSizes = nil }
+
+func TestFillDaySlotTemplates(t *testing.T) {
+	c := testConfig(t, noSizes)
+	tests := []struct {
+		name  string
+		day   int
+		games []Game
+		want  []string
+	}{
+		{
+			name:  "weekday template fills only the venue with games",
+			day:   4,
+			games: []Game{testGame(t, c, 4, "9:00", "", "North", "15U", "Red", "Blue")},
+			want: []string{
+				"09:00 Ballpark North: 15U Red vs 15U Blue",
+				"09:00 Ballpark South: open",
+				"10:30 Ballpark North: open",
+				"10:30 Ballpark South: open",
+			},
+		},
+		{
+			name:  "games off the template add their own slot",
+			day:   4,
+			games: []Game{testGame(t, c, 4, "9:45", "", "South", "10U", "Red", "Blue")},
+			want: []string{
+				"09:00 Ballpark North: open",
+				"09:00 Ballpark South: open",
+				"09:45 Ballpark North: open",
+				"09:45 Ballpark South: 10U Red vs 10U Blue",
+				"10:30 Ballpark North: open",
+				"10:30 Ballpark South: open",
+			},
+		},
+		{
+			name: "day without games fills every venue",
+			day:  11,
+			want: []string{
+				"09:00 Ballpark North: open",
+				"09:00 Ballpark South: open",
+				"10:30 Ballpark North: open",
+				"10:30 Ballpark South: open",
+				"09:00 Lakeside Main: open",
+				"10:30 Lakeside Main: open",
+			},
+		},
+		{
+			name:  "date template wins over the weekday and fills every venue",
+			day:   18,
+			games: []Game{testGame(t, c, 18, "10:00", "Lakeside", "Main", "10U", "Gold", "Green")},
+			want: []string{
+				"09:00 Ballpark North: open",
+				"09:00 Ballpark South: open",
+				"09:00 Lakeside Main: open",
+				"10:00 Lakeside Main: 10U Gold vs 10U Green",
+			},
+		},
+		{
+			name:  "empty date template leaves only the slots of the games",
+			day:   25,
+			games: []Game{testGame(t, c, 25, "9:00", "", "North", "10U", "Red", "Blue")},
+			want: []string{
+				"09:00 Ballpark North: 10U Red vs 10U Blue",
+				"09:00 Ballpark South: open",
+			},
+		},
+		{
+			name:  "day without a template has only the slots of its games",
+			day:   10,
+			games: []Game{testGame(t, c, 10, "6:00 PM", "", "South", "10U", "Red", "Blue")},
+			want: []string{
+				"18:00 Ballpark North: open",
+				"18:00 Ballpark South: 10U Red vs 10U Blue",
+			},
+		},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c, Games: tt.games}
+		if got := filledDay(s, tt.day, tt.games); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// filledDay runs s.fillDay on a January 2025 day and describes the result.
+func filledDay(s *Schedule, day int, games []Game) []string {
+	var got []string
+	for _, g := range s.fillDay(time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC), games) {
+		got = append(got, describe(g))
+	}
+	return got
+}
+
+func TestDaysAddsTemplateDates(t *testing.T) {
+	c := testConfig(t, nil)
+	s := &Schedule{Config: c}
+	var got []string
+	for _, d := range s.Days() {
+		got = append(got, d.Date.Format(DateFormat))
+	}
+	want := []string{
+		"1/4/2025", "1/11/2025", "1/18/2025",
+		"2/1/2025", "2/8/2025", "2/15/2025", "2/22/2025",
+		"3/1/2025", "3/8/2025", "3/15/2025", "3/22/2025",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Days() dates = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SlotsConfig lists the time slots every game day is expected to have, so
+// a slot with no games still appears with all of its fields open.
+//
+// Weekdays maps a day name, e.g. "Saturday", to its start times. Dates maps
+// a date to its start times and wins over the weekday; an empty list takes
+// the date off. Every date in the season with start times is on the
+// schedule, even when no games are booked on it.
+type SlotsConfig struct {
+	Weekdays map[string][]string `json:"weekdays"`
+	Dates    map[string][]string `json:"dates"`
+}
+
+// slotTemplates are the parsed SlotsConfig. Times are clock times on the
+// zero date.
+type slotTemplates struct {
+	weekdays map[time.Weekday][]time.Time
+	dates    map[time.Time][]time.Time
+}
+
+// parseSlots checks the slot templates and parses them into c.slots.
+func (c *Config) parseSlots() error {
+	c.slots = slotTemplates{
+		weekdays: make(map[time.Weekday][]time.Time),
+		dates:    make(map[time.Time][]time.Time),
+	}
+	for name, times := range c.Slots.Weekdays {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return fmt.Errorf("slots.weekdays: %q is not a day of the week", name)
+		}
+		if _, dup := c.slots.weekdays[day]; dup {
+			return fmt.Errorf("slots.weekdays: %s is listed twice", day)
+		}
+		clocks, err := c.parseSlotTimes(times)
+		if err != nil {
+			return fmt.Errorf("slots.weekdays.%s: %w", name, err)
+		}
+		c.slots.weekdays[day] = clocks
+	}
+	for value, times := range c.Slots.Dates {
+		date, err := time.Parse(DateFormat, value)
+		if err != nil {
+			return fmt.Errorf("slots.dates: %q is not a %s date", value, DateFormat)
+		}
+		if date.Before(c.startDate) || date.After(c.endDate) {
+			return fmt.Errorf("slots.dates: %s is outside the season", value)
+		}
+		if _, dup := c.slots.dates[date]; dup {
+			return fmt.Errorf("slots.dates: %s is listed twice", value)
+		}
+		clocks, err := c.parseSlotTimes(times)
+		if err != nil {
+			return fmt.Errorf("slots.dates.%s: %w", value, err)
+		}
+		c.slots.dates[date] = clocks
+	}
+	return nil
+}
+
+func (c *Config) parseSlotTimes(times []string) ([]time.Time, error) {
+	clocks := make([]time.Time, len(times))
+	for i, v := range times {
+		t, err := c.ParseTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %v", v, err)
+		}
+		clocks[i] = t
+	}
+	return clocks, nil
+}
+
+// parseWeekday accepts a full or three-letter day name in any case.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// slotTimes returns the start times expected on day, from its date template
+// or else its weekday template.
+func (c *Config) slotTimes(day time.Time) []time.Time {
+	if times, ok := c.slots.dates[day]; ok {
+		return times
+	}
+	return c.slots.weekdays[day.Weekday()]
+}
+
+// slotDates returns the dates in the season that have start times, in
+// order.
+func (c *Config) slotDates() []time.Time {
+	var dates []time.Time
+	for d := c.startDate; !d.After(c.endDate); d = d.AddDate(0, 0, 1) {
+		if len(c.slotTimes(d)) > 0 {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,262 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VenueConfig is one park the games are played at. Address is printed after
+// the name in the workbook header. Fields lists the fields filled in each
+// time slot; when it is empty the venue has Field #1 to #6.
+type VenueConfig struct {
+	Name    string        `json:"name"`
+	Address string        `json:"address"`
+	Fields  []FieldConfig `json:"fields"`
+}
+
+// FieldConfig is one field of a venue. Name is how the field is written in
+// the Location column and printed on open fields; it defaults to
+// "Field #<Number>". Size, e.g. "full" or "short", is what the fieldSizes
+// rules match.
+type FieldConfig struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+	Size   string `json:"size"`
+	Lights bool   `json:"lights"`
+}
+
+// defaultFieldCount is the number of fields a venue without a field list
+// has.
+const defaultFieldCount = 6
+
+// venueSeparator splits a Location such as "Lemon Bay Park - Field #3" into
+// its venue and field.
+const venueSeparator = " - "
+
+// Label returns the venue's name followed by its address, if it has one.
+func (v VenueConfig) Label() string {
+	if v.Address == "" {
+		return v.Name
+	}
+	return v.Name + ", " + v.Address
+}
+
+// FieldList returns the venue's fields in the order they are laid out in
+// each time slot.
+func (v VenueConfig) FieldList() []FieldConfig {
+	if len(v.Fields) > 0 {
+		return v.Fields
+	}
+	fields := make([]FieldConfig, defaultFieldCount)
+	for i := range fields {
+		fields[i] = FieldConfig{Number: i + 1, Name: fieldName(i + 1)}
+	}
+	return fields
+}
+
+// Field returns the field a location refers to, matched by name or else by
+// the "#N" number in it.
+func (v VenueConfig) Field(location string) (FieldConfig, bool) {
+	location = strings.TrimSpace(location)
+	for _, f := range v.FieldList() {
+		if strings.EqualFold(f.Name, location) {
+			return f, true
+		}
+	}
+	if n := parseField(location); n > 0 {
+		for _, f := range v.FieldList() {
+			if f.Number == n {
+				return f, true
+			}
+		}
+	}
+	return FieldConfig{}, false
+}
+
+// VenueNamed returns the venue called name, in any case.
+func (c *Config) VenueNamed(name string) (VenueConfig, bool) {
+	name = strings.TrimSpace(name)
+	for _, v := range c.Venues {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return VenueConfig{}, false
+}
+
+// Locate returns the venue and field a game is played on. venue may be
+// empty, in which case the venue is taken from a "<venue> - <field>"
+// location, or else is the first venue in the config. A venue's address may
+// follow the field, as in the calendars RenderICS writes.
+func (c *Config) Locate(venue, location string) (VenueConfig, FieldConfig, error) {
+	venue, location = c.splitLocation(venue, location)
+	v := c.Venues[0]
+	if venue != "" {
+		var ok bool
+		if v, ok = c.VenueNamed(venue); !ok {
+			return VenueConfig{}, FieldConfig{}, fmt.Errorf("%q is not one of the venues (%s)", venue, venueNames(c.Venues))
+		}
+	}
+	if v.Address != "" {
+		location = strings.TrimSpace(strings.TrimSuffix(location, ", "+v.Address))
+	}
+	f, ok := v.Field(location)
+	if !ok {
+		return v, FieldConfig{}, fmt.Errorf("%q is not one of the fields at %s (%s)", location, v.Name, fieldNames(v.FieldList()))
+	}
+	return v, f, nil
+}
+
+// splitLocation takes the venue off the front of location when venue is
+// empty and location starts with a known venue.
+func (c *Config) splitLocation(venue, location string) (string, string) {
+	venue, location = strings.TrimSpace(venue), strings.TrimSpace(location)
+	if venue != "" {
+		return venue, location
+	}
+	if i := strings.LastIndex(location, venueSeparator); i >= 0 {
+		if v, ok := c.VenueNamed(location[:i]); ok {
+			return v.Name, strings.TrimSpace(location[i+len(venueSeparator):])
+		}
+	}
+	return "", location
+}
+
+// place sets the venue, location and field of g from the venue and location
+// read from the input. A game on an unknown field keeps the field as
+// written and the number in it, or 0; checkGame reports it.
+func (c *Config) place(g *Game, venue, location string) {
+	v, f, err := c.Locate(venue, location)
+	if err != nil {
+		g.Venue, g.Location = c.splitLocation(venue, location)
+		if v.Name != "" {
+			g.Venue = v.Name
+		}
+		g.Field = parseField(g.Location)
+		return
+	}
+	g.Venue, g.Location, g.Field = v.Name, f.Name, f.Number
+}
+
+// venueGames is the share of a day's games played at one venue.
+type venueGames struct {
+	venue VenueConfig
+	games []Game
+}
+
+// groupByVenue splits games by venue, in the order of the config, keeping
+// their order within each venue. Venues not in the config come last.
+func (s *Schedule) groupByVenue(games []Game) []venueGames {
+	var groups []venueGames
+	index := make(map[string]int)
+	for _, v := range s.Config.Venues {
+		index[v.Name] = len(groups)
+		groups = append(groups, venueGames{venue: v})
+	}
+	for _, g := range games {
+		i, ok := index[g.Venue]
+		if !ok {
+			i = len(groups)
+			index[g.Venue] = i
+			groups = append(groups, venueGames{venue: VenueConfig{Name: g.Venue}})
+		}
+		groups[i].games = append(groups[i].games, g)
+	}
+	var played []venueGames
+	for _, vg := range groups {
+		if len(vg.games) > 0 {
+			played = append(played, vg)
+		}
+	}
+	return played
+}
+
+func fieldName(number int) string {
+	return fmt.Sprintf("Field #%d", number)
+}
+
+// fieldNames returns the names of fields, for messages.
+func fieldNames(fields []FieldConfig) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// venueNames returns the names of venues, for messages.
+func venueNames(venues []VenueConfig) string {
+	names := make([]string, len(venues))
+	for i, v := range venues {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// validateVenues moves a version 1 venue into Venues and checks every venue.
+func (c *Config) validateVenues() error {
+	if c.Version == 1 {
+		if len(c.Venues) > 0 {
+			return fmt.Errorf("venues needs version %d", configVersion)
+		}
+		if strings.TrimSpace(c.Venue.Name) == "" {
+			return fmt.Errorf("venue.name must not be empty")
+		}
+		c.Venues = []VenueConfig{c.Venue}
+		return c.Venues[0].validateFields("venue.fields")
+	}
+
+	if c.Venue.Name != "" || c.Venue.Address != "" || len(c.Venue.Fields) > 0 {
+		return fmt.Errorf("venue was replaced by venues in version %d", configVersion)
+	}
+	if len(c.Venues) == 0 {
+		return fmt.Errorf("venues must list at least one venue")
+	}
+	names := make(map[string]bool)
+	for i := range c.Venues {
+		v := &c.Venues[i]
+		v.Name = strings.TrimSpace(v.Name)
+		v.Address = strings.TrimSpace(v.Address)
+		if v.Name == "" {
+			return fmt.Errorf("venues[%d].name must not be empty", i)
+		}
+		if strings.Contains(v.Name, venueSeparator) {
+			return fmt.Errorf("venues[%d].name %q must not contain %q", i, v.Name, venueSeparator)
+		}
+		if names[strings.ToLower(v.Name)] {
+			return fmt.Errorf("venues[%d]: venue name %q is listed twice", i, v.Name)
+		}
+		names[strings.ToLower(v.Name)] = true
+		if err := v.validateFields(fmt.Sprintf("venues[%d].fields", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFields fills in default names and checks that numbers and names
+// are unique. key names the field list in messages.
+func (v *VenueConfig) validateFields(key string) error {
+	numbers := make(map[int]bool)
+	names := make(map[string]bool)
+	for i := range v.Fields {
+		f := &v.Fields[i]
+		if f.Number < 1 {
+			return fmt.Errorf("%s[%d].number must be a positive number", key, i)
+		}
+		if numbers[f.Number] {
+			return fmt.Errorf("%s[%d]: field number %d is listed twice", key, i, f.Number)
+		}
+		numbers[f.Number] = true
+
+		f.Name = strings.TrimSpace(f.Name)
+		if f.Name == "" {
+			f.Name = fieldName(f.Number)
+		}
+		if names[strings.ToLower(f.Name)] {
+			return fmt.Errorf("%s[%d]: field name %q is listed twice", key, i, f.Name)
+		}
+		names[strings.ToLower(f.Name)] = true
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocate(t *testing.T) {
+	c := testConfig(t, nil)
+	tests := []struct {
+		venue, location string
+		wantVenue       string
+		wantField       int
+		wantErr         bool
+	}{
+		{"", "North", "Ballpark", 1, false},
+		{"", "south", "Ballpark", 2, false},
+		{"", "#2", "Ballpark", 2, false},
+		{"", "Lakeside - Main", "Lakeside", 1, false},
+		{"lakeside", "Main", "Lakeside", 1, false},
+		{"", "Lakeside - Main, 1 Lake Rd", "Lakeside", 1, false},
+		{"", "Lakeside - Field #1", "Lakeside", 1, false},
+		{"", "Main", "", 0, true},
+		{"Hilltop", "North", "", 0, true},
+		{"", "Hilltop - North", "", 0, true},
+		{"", "Ballpark - East", "", 0, true},
+	}
+	for _, tt := range tests {
+		v, f, err := c.Locate(tt.venue, tt.location)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Locate(%q, %q): error = %v, want error %v", tt.venue, tt.location, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (v.Name != tt.wantVenue || f.Number != tt.wantField) {
+			t.Errorf("Locate(%q, %q) = %s field %d, want %s field %d", tt.venue, tt.location, v.Name, f.Number, tt.wantVenue, tt.wantField)
+		}
+	}
+}
+
+func TestSplitLocation(t *testing.T) {
+	c := testConfig(t, nil)
+	tests := []struct {
+		venue, location         string
+		wantVenue, wantLocation string
+	}{
+		{"", "North", "", "North"},
+		{"", "Lakeside - Main", "Lakeside", "Main"},
+		{"", "LAKESIDE - Main", "Lakeside", "Main"},
+		{"", "Hilltop - Main", "", "Hilltop - Main"},
+		{"Ballpark", "Lakeside - Main", "Ballpark", "Lakeside - Main"},
+		{" ", " North ", "", "North"},
+	}
+	for _, tt := range tests {
+		venue, location := c.splitLocation(tt.venue, tt.location)
+		if venue != tt.wantVenue || location != tt.wantLocation {
+			t.Errorf("splitLocation(%q, %q) = %q, %q, want %q, %q", tt.venue, tt.location, venue, location, tt.wantVenue, tt.wantLocation)
+		}
+	}
+}
+
+func TestFillDayVenues(t *testing.T) {
+	c := testConfig(t, noSizes)
+	tests := []struct {
+		name  string
+		day   int
+		games []Game
+		want  []string
+	}{
+		{
+			name: "venues are filled on their own, in config order",
+			day:  10,
+			games: []Game{
+				testGame(t, c, 10, "7:00 PM", "", "Lakeside - Main", "10U", "Gold", "Green"),
+				testGame(t, c, 10, "6:00 PM", "", "South", "10U", "Red", "Blue"),
+			},
+			want: []string{
+				"18:00 Ballpark North: open",
+				"18:00 Ballpark South: 10U Red vs 10U Blue",
+				"19:00 Lakeside Main: 10U Gold vs 10U Green",
+			},
+		},
+		{
+			name: "games on unknown fields follow the venue's fields",
+			day:  10,
+			games: []Game{
+				testGame(t, c, 10, "6:00 PM", "", "Field #9", "10U", "Gold", "Green"),
+				testGame(t, c, 10, "6:00 PM", "", "South", "10U", "Red", "Blue"),
+			},
+			want: []string{
+				"18:00 Ballpark North: open",
+				"18:00 Ballpark South: 10U Red vs 10U Blue",
+				"18:00 Ballpark Field #9: 10U Gold vs 10U Green",
+			},
+		},
+		{
+			name: "games at unknown venues come last",
+			day:  10,
+			games: []Game{
+				testGame(t, c, 10, "5:00 PM", "Hilltop", "Field #1", "10U", "Gold", "Green"),
+				testGame(t, c, 10, "6:00 PM", "", "North", "10U", "Red", "Blue"),
+			},
+			want: []string{
+				"18:00 Ballpark North: 10U Red vs 10U Blue",
+				"18:00 Ballpark South: open",
+				"17:00 Hilltop Field #1: 10U Gold vs 10U Green",
+			},
+		},
+	}
+	for _, tt := range tests {
+		s := &Schedule{Config: c, Games: tt.games}
+		if got := filledDay(s, tt.day, tt.games); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}